@@ -10,6 +10,7 @@ import (
 	"github.com/spf13/cobra"
 	"github.com/vultr/govultr/v3"
 	"github.com/vultr/vultr-cli/v3/cmd/applications"
+	"github.com/vultr/vultr-cli/v3/cmd/cost"
 	"github.com/vultr/vultr-cli/v3/cmd/ip"
 	"github.com/vultr/vultr-cli/v3/cmd/operatingsystems"
 	"github.com/vultr/vultr-cli/v3/cmd/printer"
@@ -218,6 +219,15 @@ func NewCmdBareMetal(base *cli.Base) *cobra.Command { //nolint:funlen,gocyclo
 
 			o.CreateReq = req
 
+			estimate, errEs := cmd.Flags().GetBool("estimate")
+			if errEs != nil {
+				return fmt.Errorf("error parsing flag 'estimate' for bare metal create : %v", errEs)
+			}
+
+			if estimate {
+				return o.estimate()
+			}
+
 			bm, err := o.create()
 			if err != nil {
 				return fmt.Errorf("error with bare metal create : %v", err)
@@ -318,6 +328,7 @@ Defaults to 'yes'.`,
 		`(optional) The raid configuration to use when provisioning this server. 
 Possible values: 'raid1', 'jbod', 'none''. Defaults to 'none'.`,
 	)
+	cost.AddFlag(create)
 	if err := create.MarkFlagRequired("region"); err != nil {
 		fmt.Printf("error marking bare metal create 'region' flag required: %v", err)
 		os.Exit(1)
@@ -346,6 +357,22 @@ Possible values: 'raid1', 'jbod', 'none''. Defaults to 'none'.`,
 			return nil
 		},
 		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := utils.ConfirmDelete(cmd, func() (*utils.Resource, error) {
+				bm, err := o.get()
+				if err != nil {
+					return nil, err
+				}
+				return &utils.Resource{
+					Kind:   "bare metal server",
+					ID:     bm.ID,
+					Label:  bm.Label,
+					Region: bm.Region,
+					Tags:   bm.Tags,
+				}, nil
+			}); err != nil {
+				return err
+			}
+
 			if err := o.del(); err != nil {
 				return fmt.Errorf("error deleting bare metal : %v", err)
 			}
@@ -355,6 +382,8 @@ Possible values: 'raid1', 'jbod', 'none''. Defaults to 'none'.`,
 		},
 	}
 
+	utils.AddConfirmFlags(del)
+
 	// Halt
 	halt := &cobra.Command{
 		Use:     "halt <Bare Metal ID>",
@@ -1064,6 +1093,18 @@ func (b *options) create() (*govultr.BareMetalServer, error) {
 	return bm, err
 }
 
+// estimate displays the estimated cost of the server in the create request
+func (b *options) estimate() error {
+	item, err := cost.NewEstimator(b.Base).BareMetal(b.CreateReq.Plan, b.CreateReq.Region, 1)
+	if err != nil {
+		return fmt.Errorf("error estimating bare metal cost : %v", err)
+	}
+
+	b.Base.Printer.Display(&cost.EstimatePrinter{Estimate: cost.NewEstimate(item)}, nil)
+
+	return nil
+}
+
 func (b *options) update() (*govultr.BareMetalServer, error) {
 	bm, _, err := b.Base.Client.BareMetalServer.Update(b.Base.Context, b.Base.Args[0], b.UpdateReq)
 	return bm, err
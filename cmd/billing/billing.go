@@ -4,7 +4,10 @@ package billing
 import (
 	"errors"
 	"fmt"
+	"os"
 	"strconv"
+	"strings"
+	"time"
 
 	"github.com/spf13/cobra"
 	"github.com/vultr/govultr/v3"
@@ -72,6 +75,68 @@ var (
 	# Shortened with alias commands
 	vultr-cli billing i i 123456
 	`
+
+	reportLong = `Report the spend of a month grouped by product, label or region, compared against
+the previous month, along with the groups which changed the most.
+
+The charges of a month are taken from the items of the invoices issued during or
+right after the month, by the start date of each item.  The current month has not
+been invoiced yet and is reported from the pending charges.  Labels are taken from
+the invoice item descriptions.  Regions are matched from the labels of the existing
+instances, bare metal servers, block storage, load balancers, Kubernetes clusters
+and managed databases found in the descriptions.  Charges of deleted resources are
+reported in the unknown region.
+
+The report is displayed as a table or, with the global --output flag, as JSON or
+YAML.  --csv writes it as CSV instead, with a row per group and a total row.`
+	reportExample = `
+	# Full example
+	vultr-cli billing report --month 2026-09 --group-by product
+
+	# Spend by region of the previous month
+	vultr-cli billing report --group-by region
+
+	# Export the spend by label as CSV
+	vultr-cli billing report --month 2026-09 --group-by label --csv > 2026-09.csv
+
+	# Spend by product as JSON
+	vultr-cli billing report --month 2026-09 -o json
+
+	# Shortened with alias commands
+	vultr-cli billing r -m 2026-09 -g label
+	`
+
+	budgetLong    = `Get all available commands for billing budgets`
+	budgetExample = `
+	# Full example
+	vultr-cli billing budget
+
+	# Shortened with alias commands
+	vultr-cli billing b
+	`
+
+	budgetCheckLong = `Check the pending charges of the current month against a monthly limit.
+
+The month end spend is projected linearly from the pending charges and the share of
+the month elapsed so far, counting at least one day.  The check is CRITICAL once the
+pending charges or the projection reach the limit and a WARNING once the projection
+reaches the --warn-at percentage of the limit.  The command exits with 0 when OK, 1
+on a WARNING and 2 when CRITICAL, following the convention of monitoring plugins, so
+it can be run from cron or CI.
+
+With -o json the check is printed as a JSON object with its message in the text
+field, which can be posted as is to chat webhooks.`
+	budgetCheckExample = `
+	# Full example
+	vultr-cli billing budget check --monthly-limit 5000 --warn-at 80%
+
+	# Post the result to a webhook only when the check is CRITICAL
+	vultr-cli billing budget check --monthly-limit 5000 -o json > budget.json; \
+		[ $? -eq 2 ] && curl -X POST -H 'Content-Type: application/json' -d @budget.json "$WEBHOOK_URL"
+
+	# Shortened with alias commands
+	vultr-cli billing b c -l 5000 -w 90
+	`
 )
 
 func NewCmdBilling(base *cli.Base) *cobra.Command {
@@ -251,9 +316,165 @@ func NewCmdBilling(base *cli.Base) *cobra.Command {
 		historyList,
 	)
 
+	// Report
+	report := &cobra.Command{
+		Use:     "report",
+		Short:   "Report the monthly spend",
+		Aliases: []string{"r"},
+		Long:    reportLong,
+		Example: reportExample,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			month, errMo := cmd.Flags().GetString("month")
+			if errMo != nil {
+				return fmt.Errorf("error parsing flag 'month' for billing report : %v", errMo)
+			}
+
+			groupBy, errGr := cmd.Flags().GetString("group-by")
+			if errGr != nil {
+				return fmt.Errorf("error parsing flag 'group-by' for billing report : %v", errGr)
+			}
+
+			csv, errCs := cmd.Flags().GetBool("csv")
+			if errCs != nil {
+				return fmt.Errorf("error parsing flag 'csv' for billing report : %v", errCs)
+			}
+
+			top, errTo := cmd.Flags().GetInt("top")
+			if errTo != nil {
+				return fmt.Errorf("error parsing flag 'top' for billing report : %v", errTo)
+			}
+
+			m, err := parseMonth(month, time.Now().UTC())
+			if err != nil {
+				return err
+			}
+
+			switch groupBy {
+			case groupByProduct, groupByLabel, groupByRegion:
+			default:
+				return fmt.Errorf(
+					"invalid billing report group by %q, must be one of: %s",
+					groupBy,
+					strings.Join([]string{groupByProduct, groupByLabel, groupByRegion}, ", "),
+				)
+			}
+
+			r, err := o.report(m, groupBy, top)
+			if err != nil {
+				return fmt.Errorf("error building billing report : %v", err)
+			}
+
+			if csv {
+				out, errCSV := r.CSV()
+				if errCSV != nil {
+					return fmt.Errorf("error writing billing report csv : %v", errCSV)
+				}
+				fmt.Fprintf(cmd.OutOrStdout(), "%s", out)
+				return nil
+			}
+
+			data := &ReportPrinter{Report: r}
+			o.Base.Printer.Display(data, nil)
+
+			return nil
+		},
+	}
+
+	report.Flags().StringP(
+		"month",
+		"m",
+		"",
+		"(optional) The month to report in the YYYY-MM format. Defaults to the previous month.",
+	)
+	report.Flags().StringP(
+		"group-by",
+		"g",
+		groupByProduct,
+		"(optional) How to group the spend. Possible values: 'product', 'label', 'region'.",
+	)
+	report.Flags().Bool("csv", false, "(optional) Write the report as CSV instead of the output format.")
+	report.Flags().IntP(
+		"top",
+		"t",
+		reportTopDefault,
+		"(optional) The number of biggest deltas against the previous month to highlight. 0 disables them.",
+	)
+
+	// Budget
+	budget := &cobra.Command{
+		Use:     "budget",
+		Aliases: []string{"b"},
+		Short:   "Commands to check budgets",
+		Long:    budgetLong,
+		Example: budgetExample,
+	}
+
+	// Budget Check
+	budgetCheck := &cobra.Command{
+		Use:     "check",
+		Short:   "Check the pending charges against a monthly limit",
+		Aliases: []string{"c"},
+		Long:    budgetCheckLong,
+		Example: budgetCheckExample,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			limit, errLi := cmd.Flags().GetFloat64("monthly-limit")
+			if errLi != nil {
+				return fmt.Errorf("error parsing flag 'monthly-limit' for billing budget check : %v", errLi)
+			}
+
+			warn, errWa := cmd.Flags().GetString("warn-at")
+			if errWa != nil {
+				return fmt.Errorf("error parsing flag 'warn-at' for billing budget check : %v", errWa)
+			}
+
+			if limit <= 0 {
+				return errors.New("the 'monthly-limit' for billing budget check must be positive")
+			}
+
+			warnAt, err := parseWarnAt(warn)
+			if err != nil {
+				return err
+			}
+
+			b, err := o.budgetCheck(limit, warnAt)
+			if err != nil {
+				return fmt.Errorf("error checking billing budget : %v", err)
+			}
+
+			data := &BudgetPrinter{Budget: b}
+			o.Base.Printer.Print(data)
+
+			if code := b.ExitCode(); code != 0 {
+				// the check already shows the level and its message
+				cmd.SilenceErrors = true
+				return &utils.ExitError{Code: code}
+			}
+
+			return nil
+		},
+	}
+
+	budgetCheck.Flags().Float64P("monthly-limit", "l", 0, "the monthly spend limit in USD")
+	if err := budgetCheck.MarkFlagRequired("monthly-limit"); err != nil {
+		fmt.Printf("error marking billing budget check 'monthly-limit' flag required: %v", err)
+		os.Exit(1)
+	}
+	budgetCheck.Flags().StringP(
+		"warn-at",
+		"w",
+		budgetDefaultWarnAt,
+		"(optional) the percentage of the monthly limit at which the projected spend is a warning",
+	)
+
+	budget.AddCommand(
+		budgetCheck,
+	)
+
 	cmd.AddCommand(
+		budget,
 		history,
 		invoice,
+		report,
 	)
 
 	return cmd
@@ -0,0 +1,137 @@
+package billing
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	budgetOK       string = "OK"
+	budgetWarning  string = "WARNING"
+	budgetCritical string = "CRITICAL"
+
+	budgetDefaultWarnAt string = "80%"
+)
+
+// budgetExitCodes are the exit codes of the budget levels, following the
+// convention of monitoring plugins
+var budgetExitCodes = map[string]int{
+	budgetOK:       0,
+	budgetWarning:  1,
+	budgetCritical: 2,
+}
+
+// Budget is the result of a budget check.  Text holds the message of the
+// check so the JSON can be posted as is to chat webhooks.
+type Budget struct {
+	Level          string  `json:"level"`
+	Text           string  `json:"text"`
+	MonthlyLimit   float64 `json:"monthly_limit"`
+	WarnAt         float64 `json:"warn_at_percent"`
+	PendingCharges float64 `json:"pending_charges"`
+	Projected      float64 `json:"projected"`
+	UsedPercent    float64 `json:"used_percent"`
+	ProjectedPct   float64 `json:"projected_percent"`
+	MonthElapsed   float64 `json:"month_elapsed_percent"`
+	CheckedAt      string  `json:"checked_at"`
+}
+
+// ExitCode returns the exit code of the budget level
+func (b *Budget) ExitCode() int {
+	return budgetExitCodes[b.Level]
+}
+
+// parseWarnAt parses a warning threshold given as a percentage of the limit,
+// with or without the percent sign
+func parseWarnAt(warnAt string) (float64, error) {
+	pct, err := strconv.ParseFloat(strings.TrimSpace(strings.TrimSuffix(strings.TrimSpace(warnAt), "%")), 64)
+	if err != nil || pct <= 0 || pct > 100 {
+		return 0, fmt.Errorf("invalid warn at %q, must be a percentage between 0 and 100 such as 80%%", warnAt)
+	}
+	return pct, nil
+}
+
+// monthElapsed returns the fraction of the month of now which has elapsed.
+// At least a day is considered elapsed so the projection is not inflated by
+// the first hours of the month.
+func monthElapsed(now time.Time) float64 {
+	start := time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, time.UTC)
+	month := start.AddDate(0, 1, 0).Sub(start)
+
+	elapsed := now.Sub(start)
+	if elapsed < 24*time.Hour {
+		elapsed = 24 * time.Hour
+	}
+
+	return elapsed.Hours() / month.Hours()
+}
+
+// newBudget checks the pending charges of the month against the limit.  The
+// month end spend is projected linearly from the pending charges so far.  The
+// check is critical once the pending charges or the projection reach the limit
+// and a warning once they reach the warning percentage of the limit.
+func newBudget(pending, limit, warnAt float64, now time.Time) *Budget {
+	elapsed := monthElapsed(now)
+
+	b := &Budget{
+		Level:          budgetOK,
+		MonthlyLimit:   limit,
+		WarnAt:         warnAt,
+		PendingCharges: pending,
+		Projected:      pending / elapsed,
+		MonthElapsed:   elapsed * 100,
+		CheckedAt:      now.Format(time.RFC3339),
+	}
+	b.UsedPercent = b.PendingCharges / limit * 100
+	b.ProjectedPct = b.Projected / limit * 100
+
+	switch {
+	case b.UsedPercent >= 100:
+		b.Level = budgetCritical
+		b.Text = fmt.Sprintf(
+			"pending charges of $%.2f have exceeded the monthly limit of $%.2f",
+			b.PendingCharges,
+			limit,
+		)
+	case b.ProjectedPct >= 100:
+		b.Level = budgetCritical
+		b.Text = fmt.Sprintf(
+			"projected month end spend of $%.2f (%.0f%%) will exceed the monthly limit of $%.2f",
+			b.Projected,
+			b.ProjectedPct,
+			limit,
+		)
+	case b.ProjectedPct >= warnAt:
+		b.Level = budgetWarning
+		b.Text = fmt.Sprintf(
+			"projected month end spend of $%.2f (%.0f%%) is above the warning threshold of %.0f%% of $%.2f",
+			b.Projected,
+			b.ProjectedPct,
+			warnAt,
+			limit,
+		)
+	default:
+		b.Text = fmt.Sprintf(
+			"projected month end spend of $%.2f (%.0f%%) is within the monthly limit of $%.2f",
+			b.Projected,
+			b.ProjectedPct,
+			limit,
+		)
+	}
+
+	b.Text = fmt.Sprintf("%s: %s", b.Level, b.Text)
+
+	return b
+}
+
+// budgetCheck checks the pending charges of the account against the limit
+func (b *options) budgetCheck(limit, warnAt float64) (*Budget, error) {
+	account, _, err := b.Base.Client.Account.Get(b.Base.Context)
+	if err != nil {
+		return nil, fmt.Errorf("error retrieving account : %v", err)
+	}
+
+	return newBudget(float64(account.PendingCharges), limit, warnAt, time.Now().UTC()), nil
+}
@@ -4,6 +4,7 @@ package billing
 
 import (
 	"strconv"
+	"strings"
 
 	"github.com/vultr/govultr/v3"
 	"github.com/vultr/vultr-cli/v3/cmd/printer"
@@ -219,3 +220,118 @@ func (b *BillingInvoiceItemsPrinter) Data() [][]string {
 func (b *BillingInvoiceItemsPrinter) Paging() [][]string {
 	return printer.NewPagingFromMeta(b.Meta).Compose()
 }
+
+// ======================================
+
+// ReportPrinter ...
+type ReportPrinter struct {
+	Report *Report `json:"billing_report"`
+}
+
+// JSON ...
+func (r *ReportPrinter) JSON() []byte {
+	return printer.MarshalObject(r, "json")
+}
+
+// YAML ...
+func (r *ReportPrinter) YAML() []byte {
+	return printer.MarshalObject(r, "yaml")
+}
+
+// Columns ...
+func (r *ReportPrinter) Columns() [][]string {
+	return [][]string{0: {
+		strings.ToUpper(r.Report.GroupBy),
+		r.Report.Month,
+		r.Report.PreviousMonth,
+		"DELTA",
+	}}
+}
+
+// Data ...
+func (r *ReportPrinter) Data() [][]string {
+	if len(r.Report.Lines) == 0 {
+		return [][]string{0: {"---", "---", "---", "---"}}
+	}
+
+	var data [][]string
+	for i := range r.Report.Lines {
+		data = append(data, reportRow(&r.Report.Lines[i]))
+	}
+
+	return data
+}
+
+// Paging ...
+func (r *ReportPrinter) Paging() [][]string {
+	paging := [][]string{
+		0: {"======================================"},
+		1: {"TOTAL", formatAmount(r.Report.Total), formatAmount(r.Report.PreviousTotal), formatAmount(r.Report.Delta)},
+	}
+
+	if len(r.Report.Top) > 0 {
+		paging = append(paging, []string{"======================================"}, []string{"BIGGEST DELTAS"})
+		for i := range r.Report.Top {
+			paging = append(paging, reportRow(&r.Report.Top[i]))
+		}
+	}
+
+	return paging
+}
+
+func reportRow(l *ReportLine) []string {
+	delta := formatAmount(l.Delta)
+	if l.Delta > 0 {
+		delta = "+" + delta
+	}
+
+	return []string{l.Group, formatAmount(l.Amount), formatAmount(l.Previous), delta}
+}
+
+// ======================================
+
+// BudgetPrinter marshals the budget itself so the JSON can be posted as is to
+// chat webhooks
+type BudgetPrinter struct {
+	Budget *Budget `json:"budget"`
+}
+
+// JSON ...
+func (b *BudgetPrinter) JSON() []byte {
+	return printer.MarshalObject(b.Budget, "json")
+}
+
+// YAML ...
+func (b *BudgetPrinter) YAML() []byte {
+	return printer.MarshalObject(b.Budget, "yaml")
+}
+
+// Columns ...
+func (b *BudgetPrinter) Columns() [][]string {
+	return nil
+}
+
+// Data ...
+func (b *BudgetPrinter) Data() [][]string {
+	return [][]string{
+		{"LEVEL", b.Budget.Level},
+		{"MONTHLY LIMIT", formatAmount(b.Budget.MonthlyLimit)},
+		{"WARN AT", formatPercent(b.Budget.WarnAt)},
+		{"PENDING CHARGES", formatAmount(b.Budget.PendingCharges)},
+		{"USED", formatPercent(b.Budget.UsedPercent)},
+		{"PROJECTED", formatAmount(b.Budget.Projected)},
+		{"PROJECTED USED", formatPercent(b.Budget.ProjectedPct)},
+		{"MONTH ELAPSED", formatPercent(b.Budget.MonthElapsed)},
+		{"CHECKED AT", b.Budget.CheckedAt},
+		{"MESSAGE", b.Budget.Text},
+	}
+}
+
+// Paging ...
+func (b *BudgetPrinter) Paging() [][]string {
+	return nil
+}
+
+func formatPercent(pct float64) string {
+	return strconv.FormatFloat(pct, 'f', 1, 64) + "%"
+}
@@ -0,0 +1,364 @@
+package billing
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"math"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/vultr/govultr/v3"
+	"github.com/vultr/vultr-cli/v3/cmd/utils"
+)
+
+const (
+	groupByProduct string = "product"
+	groupByLabel   string = "label"
+	groupByRegion  string = "region"
+
+	// monthLayout is the layout of the --month flag
+	monthLayout string = "2006-01"
+
+	// regionUnknown is used for charges which can not be matched to an
+	// existing resource
+	regionUnknown string = "unknown"
+
+	reportTopDefault int = 5
+)
+
+// ReportLine is the spend of a group in the month of a report and in the
+// previous month
+type ReportLine struct {
+	Group    string  `json:"group"`
+	Amount   float64 `json:"amount"`
+	Previous float64 `json:"previous"`
+	Delta    float64 `json:"delta"`
+}
+
+// Report is the spend of a month grouped by product, label or region
+type Report struct {
+	Month         string       `json:"month"`
+	PreviousMonth string       `json:"previous_month"`
+	GroupBy       string       `json:"group_by"`
+	Lines         []ReportLine `json:"lines"`
+	Top           []ReportLine `json:"top_deltas"`
+	Total         float64      `json:"total"`
+	PreviousTotal float64      `json:"previous_total"`
+	Delta         float64      `json:"delta"`
+}
+
+// parseMonth parses a month in the YYYY-MM format, defaulting to the previous
+// month which is the latest one to be fully invoiced
+func parseMonth(month string, now time.Time) (time.Time, error) {
+	current := time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, time.UTC)
+	if month == "" {
+		return current.AddDate(0, -1, 0), nil
+	}
+
+	m, err := time.Parse(monthLayout, month)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid month %q, must be in the YYYY-MM format", month)
+	}
+
+	if m.After(current) {
+		return time.Time{}, fmt.Errorf("invalid month %s, must not be in the future", month)
+	}
+
+	return m, nil
+}
+
+// report builds the report of the month grouped by the group by, along with
+// the biggest deltas against the previous month
+func (b *options) report(month time.Time, groupBy string, top int) (*Report, error) {
+	invoices, err := utils.ListAll(func(opts *govultr.ListOptions) ([]govultr.Invoice, *govultr.Meta, error) {
+		invs, meta, _, err := b.Base.Client.Billing.ListInvoices(b.Base.Context, opts)
+		return invs, meta, err
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error retrieving invoices : %v", err)
+	}
+
+	previous := month.AddDate(0, -1, 0)
+
+	items, err := b.monthItems(month, invoices)
+	if err != nil {
+		return nil, err
+	}
+
+	prevItems, err := b.monthItems(previous, invoices)
+	if err != nil {
+		return nil, err
+	}
+
+	key := func(item *govultr.InvoiceItem) string { return item.Product }
+	switch groupBy {
+	case groupByLabel:
+		key = func(item *govultr.InvoiceItem) string { return item.Description }
+	case groupByRegion:
+		regions, errRe := b.regions()
+		if errRe != nil {
+			return nil, errRe
+		}
+		key = func(item *govultr.InvoiceItem) string { return matchRegion(item.Description, regions) }
+	}
+
+	r := &Report{
+		Month:         month.Format(monthLayout),
+		PreviousMonth: previous.Format(monthLayout),
+		GroupBy:       groupBy,
+		Lines:         []ReportLine{},
+		Top:           []ReportLine{},
+	}
+
+	index := map[string]int{}
+	line := func(group string) *ReportLine {
+		i, ok := index[group]
+		if !ok {
+			i = len(r.Lines)
+			index[group] = i
+			r.Lines = append(r.Lines, ReportLine{Group: group})
+		}
+		return &r.Lines[i]
+	}
+
+	for i := range items {
+		line(key(&items[i])).Amount += float64(items[i].Total)
+		r.Total += float64(items[i].Total)
+	}
+
+	for i := range prevItems {
+		line(key(&prevItems[i])).Previous += float64(prevItems[i].Total)
+		r.PreviousTotal += float64(prevItems[i].Total)
+	}
+
+	for i := range r.Lines {
+		r.Lines[i].Delta = r.Lines[i].Amount - r.Lines[i].Previous
+	}
+	r.Delta = r.Total - r.PreviousTotal
+
+	sort.SliceStable(r.Lines, func(i, j int) bool {
+		if r.Lines[i].Amount == r.Lines[j].Amount {
+			return r.Lines[i].Group < r.Lines[j].Group
+		}
+		return r.Lines[i].Amount > r.Lines[j].Amount
+	})
+
+	deltas := append([]ReportLine{}, r.Lines...)
+	sort.SliceStable(deltas, func(i, j int) bool {
+		return math.Abs(deltas[i].Delta) > math.Abs(deltas[j].Delta)
+	})
+	for i := 0; i < len(deltas) && i < top && deltas[i].Delta != 0; i++ {
+		r.Top = append(r.Top, deltas[i])
+	}
+
+	return r, nil
+}
+
+// monthItems retrieves the invoice items of the month.  The charges of a month
+// are invoiced at the start of the next month, so the invoices dated in the
+// month or the next one are searched for items starting in the month.  The
+// current month has not been invoiced yet and uses the pending charges.
+func (b *options) monthItems(month time.Time, invoices []govultr.Invoice) ([]govultr.InvoiceItem, error) {
+	now := time.Now().UTC()
+	if month.Year() == now.Year() && month.Month() == now.Month() {
+		items, err := listPages[govultr.InvoiceItem](b, "/v2/billing/pending-charges", "pending_charges")
+		if err != nil {
+			return nil, fmt.Errorf("error retrieving pending charges : %v", err)
+		}
+		return items, nil
+	}
+
+	prefix := month.Format(monthLayout)
+	next := month.AddDate(0, 1, 0).Format(monthLayout)
+
+	var items []govultr.InvoiceItem
+	for i := range invoices {
+		if !strings.HasPrefix(invoices[i].Date, prefix) && !strings.HasPrefix(invoices[i].Date, next) {
+			continue
+		}
+
+		id := invoices[i].ID
+		invItems, err := utils.ListAll(func(opts *govultr.ListOptions) ([]govultr.InvoiceItem, *govultr.Meta, error) {
+			items, meta, _, err := b.Base.Client.Billing.ListInvoiceItems(b.Base.Context, id, opts)
+			return items, meta, err
+		})
+		if err != nil {
+			return nil, fmt.Errorf("error retrieving items of invoice %d : %v", id, err)
+		}
+
+		for j := range invItems {
+			if strings.HasPrefix(invItems[j].StartDate, prefix) {
+				items = append(items, invItems[j])
+			}
+		}
+	}
+
+	return items, nil
+}
+
+// regions maps the labels of the existing resources to their regions since
+// invoice items only describe the resource they charge for
+func (b *options) regions() (map[string]string, error) {
+	regions := map[string]string{}
+
+	instances, err := utils.ListAll(func(opts *govultr.ListOptions) ([]govultr.Instance, *govultr.Meta, error) {
+		instances, meta, _, err := b.Base.Client.Instance.List(b.Base.Context, opts)
+		return instances, meta, err
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error retrieving instances : %v", err)
+	}
+	for i := range instances {
+		regions[instances[i].Label] = instances[i].Region
+	}
+
+	bms, err := utils.ListAll(func(opts *govultr.ListOptions) ([]govultr.BareMetalServer, *govultr.Meta, error) {
+		bms, meta, _, err := b.Base.Client.BareMetalServer.List(b.Base.Context, opts)
+		return bms, meta, err
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error retrieving bare metal servers : %v", err)
+	}
+	for i := range bms {
+		regions[bms[i].Label] = bms[i].Region
+	}
+
+	bss, err := utils.ListAll(func(opts *govultr.ListOptions) ([]govultr.BlockStorage, *govultr.Meta, error) {
+		bss, meta, _, err := b.Base.Client.BlockStorage.List(b.Base.Context, opts)
+		return bss, meta, err
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error retrieving block storage : %v", err)
+	}
+	for i := range bss {
+		regions[bss[i].Label] = bss[i].Region
+	}
+
+	lbs, err := utils.ListAll(func(opts *govultr.ListOptions) ([]govultr.LoadBalancer, *govultr.Meta, error) {
+		lbs, meta, _, err := b.Base.Client.LoadBalancer.List(b.Base.Context, opts)
+		return lbs, meta, err
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error retrieving load balancers : %v", err)
+	}
+	for i := range lbs {
+		regions[lbs[i].Label] = lbs[i].Region
+	}
+
+	clusters, err := utils.ListAll(func(opts *govultr.ListOptions) ([]govultr.Cluster, *govultr.Meta, error) {
+		clusters, meta, _, err := b.Base.Client.Kubernetes.ListClusters(b.Base.Context, opts)
+		return clusters, meta, err
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error retrieving kubernetes clusters : %v", err)
+	}
+	for i := range clusters {
+		regions[clusters[i].Label] = clusters[i].Region
+	}
+
+	dbs, err := listPages[govultr.Database](b, "/v2/databases", "databases")
+	if err != nil {
+		return nil, fmt.Errorf("error retrieving databases : %v", err)
+	}
+	for i := range dbs {
+		regions[dbs[i].Label] = dbs[i].Region
+		for j := range dbs[i].ReadReplicas {
+			regions[dbs[i].ReadReplicas[j].Label] = dbs[i].ReadReplicas[j].Region
+		}
+	}
+
+	delete(regions, "")
+
+	return regions, nil
+}
+
+// listPages retrieves every page of a list which govultr does not page, since
+// ListPendingCharges drops the meta of the response and DBListOptions has no
+// cursor.  The items are read from the key of the response.
+func listPages[T any](b *options, uri, key string) ([]T, error) {
+	return utils.ListAll(func(opts *govultr.ListOptions) ([]T, *govultr.Meta, error) {
+		req, err := b.Base.Client.NewRequest(b.Base.Context, http.MethodGet, uri, nil)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		q := req.URL.Query()
+		q.Set("per_page", strconv.Itoa(opts.PerPage))
+		if opts.Cursor != "" {
+			q.Set("cursor", opts.Cursor)
+		}
+		req.URL.RawQuery = q.Encode()
+
+		body := map[string]json.RawMessage{}
+		if _, err := b.Base.Client.DoWithContext(b.Base.Context, req, &body); err != nil {
+			return nil, nil, err
+		}
+
+		var items []T
+		if raw, ok := body[key]; ok {
+			if err := json.Unmarshal(raw, &items); err != nil {
+				return nil, nil, err
+			}
+		}
+
+		meta := &govultr.Meta{}
+		if raw, ok := body["meta"]; ok {
+			if err := json.Unmarshal(raw, meta); err != nil {
+				return nil, nil, err
+			}
+		}
+
+		return items, meta, nil
+	})
+}
+
+// matchRegion returns the region of the resource with the longest label found
+// in the description
+func matchRegion(description string, regions map[string]string) string {
+	region := regionUnknown
+	longest := 0
+	for label := range regions {
+		if len(label) > longest && strings.Contains(description, label) {
+			region = regions[label]
+			longest = len(label)
+		}
+	}
+	return region
+}
+
+// CSV returns the report as CSV with a row per group and a total row
+func (r *Report) CSV() ([]byte, error) {
+	var buf bytes.Buffer
+	w := csv.NewWriter(&buf)
+
+	records := [][]string{{r.GroupBy, r.Month, r.PreviousMonth, "delta"}}
+	for i := range r.Lines {
+		records = append(records, []string{
+			r.Lines[i].Group,
+			formatAmount(r.Lines[i].Amount),
+			formatAmount(r.Lines[i].Previous),
+			formatAmount(r.Lines[i].Delta),
+		})
+	}
+	records = append(records, []string{
+		"total",
+		formatAmount(r.Total),
+		formatAmount(r.PreviousTotal),
+		formatAmount(r.Delta),
+	})
+
+	if err := w.WriteAll(records); err != nil {
+		return nil, err
+	}
+
+	return buf.Bytes(), nil
+}
+
+func formatAmount(amount float64) string {
+	return strconv.FormatFloat(amount, 'f', utils.FloatPrecision, 64)
+}
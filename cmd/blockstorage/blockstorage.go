@@ -6,9 +6,12 @@ import (
 	"errors"
 	"fmt"
 	"os"
+	"strconv"
 
 	"github.com/spf13/cobra"
 	"github.com/vultr/govultr/v3"
+	"github.com/vultr/vultr-cli/v3/cmd/bulk"
+	"github.com/vultr/vultr-cli/v3/cmd/cost"
 	"github.com/vultr/vultr-cli/v3/cmd/printer"
 	"github.com/vultr/vultr-cli/v3/cmd/utils"
 	"github.com/vultr/vultr-cli/v3/pkg/cli"
@@ -46,6 +49,12 @@ var (
 
 	#Shortened with aliased commands
 	vultr-cli bs d 67181686-5455-4ebb-81eb-7299f3506e2c
+
+	#Delete every unattached block storage in lax
+	vultr-cli block-storage delete --selector='attached=false,region=lax'
+
+	#Delete every unattached block storage older than 2 weeks, without the confirmation prompt
+	vultr-cli block-storage delete --selector='attached=false' --older-than='2w' --yes
 	`
 
 	detachLong    = `Detach a block storage resource from an instance`
@@ -94,6 +103,9 @@ var (
 	`
 )
 
+// selectorKeys are the keys supported by the block storage bulk --selector flag
+var selectorKeys = []string{"attached", "instance", "region", "label", "status", "type", "bootable"}
+
 // NewCmdBlockStorage provides the command for block storage to the CLI
 func NewCmdBlockStorage(base *cli.Base) *cobra.Command { //nolint:gocyclo
 	o := &options{Base: base}
@@ -213,6 +225,11 @@ func NewCmdBlockStorage(base *cli.Base) *cobra.Command { //nolint:gocyclo
 				return fmt.Errorf("error parsing 'bootable' flag for block storage create : %v", errBt)
 			}
 
+			estimate, errEs := cmd.Flags().GetBool("estimate")
+			if errEs != nil {
+				return fmt.Errorf("error parsing 'estimate' flag for block storage create : %v", errEs)
+			}
+
 			o.CreateReq = &govultr.BlockStorageCreate{
 				Region:     reg,
 				SizeGB:     size,
@@ -223,6 +240,10 @@ func NewCmdBlockStorage(base *cli.Base) *cobra.Command { //nolint:gocyclo
 				Bootable:   &blockBootable,
 			}
 
+			if estimate {
+				return o.estimate()
+			}
+
 			bs, err := o.create()
 			if err != nil {
 				return fmt.Errorf("error creating block storage : %v", err)
@@ -265,6 +286,7 @@ func NewCmdBlockStorage(base *cli.Base) *cobra.Command { //nolint:gocyclo
 		"",
 		"(Optional) Allows you to create a block device as a clone of an existing block snapshot.",
 	)
+	cost.AddFlag(create)
 
 	// Delete
 	del := &cobra.Command{
@@ -273,13 +295,39 @@ func NewCmdBlockStorage(base *cli.Base) *cobra.Command { //nolint:gocyclo
 		Aliases: []string{"d", "destroy"},
 		Long:    deleteLong,
 		Example: deleteExample,
-		Args: func(cmd *cobra.Command, args []string) error {
-			if len(args) < 1 {
-				return errors.New("please provide a block storage ID")
-			}
-			return nil
-		},
+		Args:    bulk.Args("please provide a block storage ID"),
 		RunE: func(cmd *cobra.Command, args []string) error {
+			bo, errBu := bulk.GetFlags(cmd, selectorKeys)
+			if errBu != nil {
+				return fmt.Errorf("error parsing bulk flags for block storage delete : %v", errBu)
+			}
+
+			if bo.Enabled() {
+				items, err := o.selectBlockStorages(bo)
+				if err != nil {
+					return fmt.Errorf("error selecting block storages : %v", err)
+				}
+
+				return bo.Execute(o.Base.Printer, "block storage delete", items, func(id string) error {
+					return o.Base.Client.BlockStorage.Delete(o.Base.Context, id)
+				})
+			}
+
+			if err := utils.ConfirmDelete(cmd, func() (*utils.Resource, error) {
+				bs, err := o.get()
+				if err != nil {
+					return nil, err
+				}
+				return &utils.Resource{
+					Kind:   "block storage",
+					ID:     bs.ID,
+					Label:  bs.Label,
+					Region: bs.Region,
+				}, nil
+			}); err != nil {
+				return err
+			}
+
 			if err := o.del(); err != nil {
 				return fmt.Errorf("error deleting block storage : %v", err)
 			}
@@ -290,6 +338,8 @@ func NewCmdBlockStorage(base *cli.Base) *cobra.Command { //nolint:gocyclo
 		},
 	}
 
+	bulk.AddDeleteFlags(del, selectorKeys)
+
 	// Attach
 	attach := &cobra.Command{
 		Use:     "attach <Block Storage ID>",
@@ -475,6 +525,45 @@ func (o *options) list() ([]govultr.BlockStorage, *govultr.Meta, error) {
 	return bs, meta, err
 }
 
+func (o *options) listAll() ([]govultr.BlockStorage, error) {
+	return utils.ListAll(func(opts *govultr.ListOptions) ([]govultr.BlockStorage, *govultr.Meta, error) {
+		bs, meta, _, err := o.Base.Client.BlockStorage.List(o.Base.Context, opts)
+		return bs, meta, err
+	})
+}
+
+// selectBlockStorages returns the block storages matching the bulk selector
+// and age
+func (o *options) selectBlockStorages(bo *bulk.Options) ([]bulk.Item, error) {
+	bs, err := o.listAll()
+	if err != nil {
+		return nil, err
+	}
+
+	var items []bulk.Item
+	for i := range bs {
+		if bo.Selector != nil && !bo.Selector.Matches(map[string][]string{
+			"attached": {strconv.FormatBool(bs[i].AttachedToInstance != "")},
+			"instance": {bs[i].AttachedToInstance},
+			"region":   {bs[i].Region},
+			"label":    {bs[i].Label},
+			"status":   {bs[i].Status},
+			"type":     {bs[i].BlockType},
+			"bootable": {strconv.FormatBool(bs[i].Bootable)},
+		}) {
+			continue
+		}
+
+		if bo.OlderThan > 0 && !bulk.OlderThan(bs[i].DateCreated, bo.OlderThan) {
+			continue
+		}
+
+		items = append(items, bulk.Item{ID: bs[i].ID, Name: bs[i].Label, Region: bs[i].Region})
+	}
+
+	return items, nil
+}
+
 func (o *options) get() (*govultr.BlockStorage, error) {
 	bs, _, err := o.Base.Client.BlockStorage.Get(o.Base.Context, o.Base.Args[0])
 	return bs, err
@@ -485,6 +574,19 @@ func (o *options) create() (*govultr.BlockStorage, error) {
 	return bs, err
 }
 
+// estimate displays the estimated cost of the block storage in the create
+// request
+func (o *options) estimate() error {
+	item, err := cost.NewEstimator(o.Base).BlockStorage(o.CreateReq.SizeGB, o.CreateReq.BlockType, o.CreateReq.Region, 1)
+	if err != nil {
+		return fmt.Errorf("error estimating block storage cost : %v", err)
+	}
+
+	o.Base.Printer.Display(&cost.EstimatePrinter{Estimate: cost.NewEstimate(item)}, nil)
+
+	return nil
+}
+
 func (o *options) del() error {
 	return o.Base.Client.BlockStorage.Delete(o.Base.Context, o.Base.Args[0])
 }
@@ -0,0 +1,448 @@
+// Package bulk provides the common functionality for running an operation
+// across many resources selected by a selector or by age
+package bulk
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/vultr/vultr-cli/v3/cmd/printer"
+	"github.com/vultr/vultr-cli/v3/cmd/utils"
+)
+
+const (
+	// ConcurrencyDefault is the default number of operations run at once
+	ConcurrencyDefault int = 4
+	// RequestInterval is the minimum time between the start of two operations,
+	// which keeps bulk operations within the API rate limit
+	RequestInterval time.Duration = 100 * time.Millisecond
+
+	ResultSuccess string = "success"
+	ResultFailed  string = "failed"
+
+	hoursPerDay  int = 24
+	hoursPerWeek int = 168
+)
+
+// Options holds the bulk flags of a command
+type Options struct {
+	Selector    *Selector
+	OlderThan   time.Duration
+	Yes         bool
+	Force       bool
+	Concurrency int
+
+	// deletion is set for delete commands, where the deletion protection
+	// applies
+	deletion bool
+}
+
+// Enabled returns whether the command should operate in bulk mode
+func (o *Options) Enabled() bool {
+	return o.Selector != nil || o.OlderThan > 0
+}
+
+// AddFlags adds the bulk flags to a command.  The keys are the selector keys
+// supported by the resource and are listed in the flag usage.  When age is
+// true, the --older-than flag is also added.
+func AddFlags(cmd *cobra.Command, keys []string, age bool) {
+	cmd.Flags().String(
+		"selector",
+		"",
+		fmt.Sprintf(
+			"(optional) Run on every resource matching the comma-separated key=value or key!=value requirements. "+
+				"Supported keys: %s",
+			strings.Join(keys, ", "),
+		),
+	)
+
+	if age {
+		cmd.Flags().String(
+			"older-than",
+			"",
+			"(optional) Run on every resource created before the age, e.g. '30d', '2w' or '12h'",
+		)
+	}
+
+	cmd.Flags().BoolP("yes", "y", false, "(optional) Skip the confirmation prompt")
+	cmd.Flags().Int(
+		"concurrency",
+		ConcurrencyDefault,
+		"(optional) The number of operations to run at once in bulk mode",
+	)
+}
+
+// AddDeleteFlags adds the bulk flags, including --older-than, to a delete
+// command along with the --force flag to override the deletion protection.
+// Single ID deletes are expected to use the --yes and --force flags through
+// utils.ConfirmDelete.
+func AddDeleteFlags(cmd *cobra.Command, keys []string) {
+	AddFlags(cmd, keys, true)
+	cmd.Flags().Bool("force", false, "(optional) Delete resources covered by the deletion protection in the config")
+}
+
+// GetFlags parses the bulk flags added by AddFlags and validates the selector
+// against the supported keys
+func GetFlags(cmd *cobra.Command, keys []string) (*Options, error) {
+	o := &Options{}
+
+	selector, errSe := cmd.Flags().GetString("selector")
+	if errSe != nil {
+		return nil, fmt.Errorf("error parsing flag 'selector' : %v", errSe)
+	}
+
+	if selector != "" {
+		sel, err := ParseSelector(selector)
+		if err != nil {
+			return nil, err
+		}
+
+		if err := sel.Validate(keys); err != nil {
+			return nil, err
+		}
+
+		o.Selector = sel
+	}
+
+	if cmd.Flags().Lookup("older-than") != nil {
+		olderThan, errOl := cmd.Flags().GetString("older-than")
+		if errOl != nil {
+			return nil, fmt.Errorf("error parsing flag 'older-than' : %v", errOl)
+		}
+
+		if olderThan != "" {
+			age, err := ParseAge(olderThan)
+			if err != nil {
+				return nil, err
+			}
+			o.OlderThan = age
+		}
+	}
+
+	yes, errYe := cmd.Flags().GetBool("yes")
+	if errYe != nil {
+		return nil, fmt.Errorf("error parsing flag 'yes' : %v", errYe)
+	}
+	o.Yes = yes || utils.AssumeYes()
+
+	if cmd.Flags().Lookup("force") != nil {
+		force, errFo := cmd.Flags().GetBool("force")
+		if errFo != nil {
+			return nil, fmt.Errorf("error parsing flag 'force' : %v", errFo)
+		}
+		o.Force = force
+		o.deletion = true
+	}
+
+	concurrency, errCo := cmd.Flags().GetInt("concurrency")
+	if errCo != nil {
+		return nil, fmt.Errorf("error parsing flag 'concurrency' : %v", errCo)
+	}
+
+	if concurrency < 1 {
+		return nil, errors.New("concurrency must be at least 1")
+	}
+	o.Concurrency = concurrency
+
+	return o, nil
+}
+
+// Args returns a cobra positional args validator which requires the resource
+// ID unless the command is run in bulk mode
+func Args(msg string) cobra.PositionalArgs {
+	return func(cmd *cobra.Command, args []string) error {
+		selector, _ := cmd.Flags().GetString("selector")
+		olderThan := ""
+		if cmd.Flags().Lookup("older-than") != nil {
+			olderThan, _ = cmd.Flags().GetString("older-than")
+		}
+
+		if selector != "" || olderThan != "" {
+			if len(args) > 0 {
+				return errors.New("a resource ID cannot be combined with --selector or --older-than")
+			}
+			return nil
+		}
+
+		if len(args) < 1 {
+			return errors.New(msg)
+		}
+		return nil
+	}
+}
+
+// ======================================
+
+type requirement struct {
+	key    string
+	value  string
+	negate bool
+}
+
+// Selector matches resources on a set of requirements which must all be met
+type Selector struct {
+	requirements []requirement
+}
+
+// ParseSelector parses a comma-separated list of key=value and key!=value
+// requirements
+func ParseSelector(s string) (*Selector, error) {
+	sel := &Selector{}
+	for _, part := range strings.Split(s, ",") {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+
+		req := requirement{}
+		key, value, ok := strings.Cut(part, "!=")
+		if ok {
+			req.negate = true
+		} else {
+			key, value, ok = strings.Cut(part, "=")
+			if !ok {
+				return nil, fmt.Errorf("invalid selector requirement %q, must be key=value or key!=value", part)
+			}
+		}
+
+		req.key = strings.ToLower(strings.TrimSpace(key))
+		req.value = strings.TrimSpace(value)
+		if req.key == "" {
+			return nil, fmt.Errorf("invalid selector requirement %q, missing key", part)
+		}
+
+		sel.requirements = append(sel.requirements, req)
+	}
+
+	if len(sel.requirements) == 0 {
+		return nil, errors.New("selector must have at least one requirement")
+	}
+
+	return sel, nil
+}
+
+// Validate checks that the selector only uses the supported keys
+func (s *Selector) Validate(keys []string) error {
+	supported := map[string]bool{}
+	for i := range keys {
+		supported[keys[i]] = true
+	}
+
+	for i := range s.requirements {
+		if !supported[s.requirements[i].key] {
+			return fmt.Errorf(
+				"unsupported selector key %q, must be one of: %s",
+				s.requirements[i].key,
+				strings.Join(keys, ", "),
+			)
+		}
+	}
+
+	return nil
+}
+
+// Matches returns whether the resource fields meet all the requirements.  A
+// field can hold multiple values, such as tags, in which case an equality
+// requirement matches when any of the values is equal.
+func (s *Selector) Matches(fields map[string][]string) bool {
+	for i := range s.requirements {
+		found := false
+		for _, v := range fields[s.requirements[i].key] {
+			if strings.EqualFold(v, s.requirements[i].value) {
+				found = true
+				break
+			}
+		}
+
+		if found == s.requirements[i].negate {
+			return false
+		}
+	}
+
+	return true
+}
+
+// ParseAge parses a duration which, in addition to the units supported by
+// time.ParseDuration, can be expressed in days (d) or weeks (w)
+func ParseAge(s string) (time.Duration, error) {
+	s = strings.TrimSpace(s)
+	for suffix, hours := range map[string]int{"d": hoursPerDay, "w": hoursPerWeek} {
+		if n, ok := strings.CutSuffix(s, suffix); ok {
+			v, err := strconv.Atoi(n)
+			if err != nil || v < 0 {
+				return 0, fmt.Errorf("invalid age %q", s)
+			}
+			return time.Duration(v*hours) * time.Hour, nil
+		}
+	}
+
+	d, err := time.ParseDuration(s)
+	if err != nil || d < 0 {
+		return 0, fmt.Errorf("invalid age %q, must be a duration such as '30d', '2w' or '12h'", s)
+	}
+
+	return d, nil
+}
+
+// OlderThan returns whether the API creation date is further in the past than
+// the age.  Dates which cannot be parsed are never considered older.
+func OlderThan(dateCreated string, age time.Duration) bool {
+	created, err := time.Parse(time.RFC3339, dateCreated)
+	if err != nil {
+		return false
+	}
+
+	return time.Since(created) > age
+}
+
+// ======================================
+
+// Item is a resource selected for a bulk operation
+type Item struct {
+	ID     string
+	Name   string
+	Region string
+	Tags   []string
+}
+
+// Result is the outcome of the bulk operation on a single resource
+type Result struct {
+	ID     string `json:"id"`
+	Name   string `json:"name"`
+	Result string `json:"result"`
+	Error  string `json:"error,omitempty"`
+}
+
+// Confirm lists the items and prompts for confirmation of the action on
+// stderr/stdin, so the output of the command is left clean, unless the prompt was skipped with --yes
+func (o *Options) Confirm(action string, items []Item) error {
+	if o.Yes {
+		return nil
+	}
+
+	fmt.Fprintf(os.Stderr, "The following %d resources will be affected by %s:\n", len(items), action)
+	for i := range items {
+		fmt.Fprintf(os.Stderr, "  %s\t%s\t%s\n", items[i].ID, items[i].Name, items[i].Region)
+	}
+
+	return utils.Prompt(os.Stdin, os.Stderr, "Proceed?")
+}
+
+// Protected returns an error listing the items covered by the deletion
+// protection in the config, unless it was overridden with --force
+func (o *Options) Protected(items []Item) error {
+	protection := utils.GetProtection()
+	if !o.deletion || o.Force || protection.Empty() {
+		return nil
+	}
+
+	var protected []string
+	for i := range items {
+		r := &utils.Resource{ID: items[i].ID, Label: items[i].Name, Region: items[i].Region, Tags: items[i].Tags}
+		if rule := protection.Match(r); rule != "" {
+			protected = append(protected, fmt.Sprintf("%s (%s)", items[i].ID, rule))
+		}
+	}
+
+	if len(protected) > 0 {
+		return fmt.Errorf(
+			"the selection includes resources protected from deletion, use --force to delete them : %s",
+			strings.Join(protected, ", "),
+		)
+	}
+
+	return nil
+}
+
+// Run calls fn for each item with at most o.Concurrency calls running at once
+// and at least RequestInterval between the start of two calls.  The results
+// are returned in the order of the items.
+func (o *Options) Run(items []Item, fn func(id string) error) []Result {
+	results := make([]Result, len(items))
+	sem := make(chan struct{}, o.Concurrency)
+	ticker := time.NewTicker(RequestInterval)
+	defer ticker.Stop()
+
+	var wg sync.WaitGroup
+	for i := range items {
+		if i > 0 {
+			<-ticker.C
+		}
+
+		sem <- struct{}{}
+		wg.Add(1)
+		go func(i int) {
+			defer func() {
+				<-sem
+				wg.Done()
+			}()
+
+			results[i] = Result{ID: items[i].ID, Name: items[i].Name, Result: ResultSuccess}
+			if err := fn(items[i].ID); err != nil {
+				results[i].Result = ResultFailed
+				results[i].Error = err.Error()
+			}
+		}(i)
+	}
+	wg.Wait()
+
+	return results
+}
+
+// Failed returns the number of failed results
+func Failed(results []Result) int {
+	n := 0
+	for i := range results {
+		if results[i].Result == ResultFailed {
+			n++
+		}
+	}
+	return n
+}
+
+// SortItems sorts the items by name and then ID for a stable confirmation list
+func SortItems(items []Item) {
+	sort.Slice(items, func(i, j int) bool {
+		if items[i].Name == items[j].Name {
+			return items[i].ID < items[j].ID
+		}
+		return items[i].Name < items[j].Name
+	})
+}
+
+// Execute checks the deletion protection and confirms the action on the items,
+// runs it and displays the per-item results.  An error is returned when any of the operations failed.
+func (o *Options) Execute(p *printer.Output, action string, items []Item, fn func(id string) error) error {
+	if len(items) == 0 {
+		p.Display(printer.Info("no resources matched the selection"), nil)
+		return nil
+	}
+
+	SortItems(items)
+
+	if err := o.Protected(items); err != nil {
+		return err
+	}
+
+	if err := o.Confirm(action, items); err != nil {
+		return err
+	}
+
+	results := o.Run(items, fn)
+	failed := Failed(results)
+
+	p.Print(&ResultsPrinter{Results: results})
+
+	if failed > 0 {
+		return fmt.Errorf("%s failed on %d of %d resources", action, failed, len(results))
+	}
+
+	return nil
+}
@@ -0,0 +1,59 @@
+package bulk
+
+import (
+	"github.com/vultr/vultr-cli/v3/cmd/printer"
+)
+
+// ResultsPrinter ...
+type ResultsPrinter struct {
+	Results []Result `json:"results"`
+}
+
+// JSON ...
+func (r *ResultsPrinter) JSON() []byte {
+	return printer.MarshalObject(r, "json")
+}
+
+// YAML ...
+func (r *ResultsPrinter) YAML() []byte {
+	return printer.MarshalObject(r, "yaml")
+}
+
+// Columns ...
+func (r *ResultsPrinter) Columns() [][]string {
+	return [][]string{0: {
+		"ID",
+		"NAME",
+		"RESULT",
+		"ERROR",
+	}}
+}
+
+// Data ...
+func (r *ResultsPrinter) Data() [][]string {
+	if len(r.Results) == 0 {
+		return [][]string{0: {"---", "---", "---", "---"}}
+	}
+
+	var data [][]string
+	for i := range r.Results {
+		errMsg := r.Results[i].Error
+		if errMsg == "" {
+			errMsg = "---"
+		}
+
+		data = append(data, []string{
+			r.Results[i].ID,
+			r.Results[i].Name,
+			r.Results[i].Result,
+			errMsg,
+		})
+	}
+
+	return data
+}
+
+// Paging ...
+func (r *ResultsPrinter) Paging() [][]string {
+	return (&printer.Total{Total: len(r.Results)}).Compose()
+}
@@ -0,0 +1,328 @@
+package cost
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/vultr/govultr/v3"
+	"github.com/vultr/vultr-cli/v3/cmd/utils"
+	"github.com/vultr/vultr-cli/v3/pkg/cli"
+	"gopkg.in/yaml.v3"
+)
+
+var (
+	long    = `Estimate the cost of resources before creating them.`
+	example = `
+	# Full example
+	vultr-cli cost estimate --file manifest.yaml
+	`
+	estimateLong = `Estimate the monthly and hourly cost of the resources in a YAML or JSON manifest
+without creating anything.
+
+Prices are looked up from the instance, bare metal and managed database plans and
+the object storage tiers.  Block storage is priced from the published per GB prices
+and automatic backups at 20% of the plan since the API does not provide them, so
+these costs are approximate and marked with a ~ in the output.  Hourly costs are the monthly price spread over
+the 672 hours after which hourly billing reaches the monthly price.  Usage based
+charges such as bandwidth and the Kubernetes control plane are not included.  A
+region is optional and, when given, is checked against the plan's locations.
+
+The manifest has the following format:
+
+	instances:
+	  - plan: vc2-1c-1gb
+	    region: ewr
+	    quantity: 2
+	    auto_backups: true
+	bare_metal:
+	  - plan: vbm-4c-32gb
+	    region: ewr
+	block_storage:
+	  - size_gb: 100
+	    block_type: high_perf
+	kubernetes:
+	  - region: ewr
+	    node_pools:
+	      - label: workers
+	        plan: vc2-2c-4gb
+	        quantity: 3
+	databases:
+	  - plan: vultr-dbaas-startup-cc-1-55-2
+	    region: ewr
+	object_storage:
+	  - tier: tier_010k_5000m
+`
+	estimateExample = `
+	# Full example
+	vultr-cli cost estimate --file manifest.yaml
+
+	# Estimate a single create command instead
+	vultr-cli instance create --region ewr --plan vc2-1c-1gb --os 2284 --estimate
+	`
+)
+
+// NewCmdCost provides the CLI command for cost estimation
+func NewCmdCost(base *cli.Base) *cobra.Command {
+	o := &options{Base: base}
+
+	cmd := &cobra.Command{
+		Use:     "cost",
+		Short:   "Commands to estimate costs",
+		Long:    long,
+		Example: example,
+		PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
+			utils.SetOptions(o.Base, cmd, args)
+			if !o.Base.HasAuth() {
+				return errors.New(utils.APIKeyError)
+			}
+			return nil
+		},
+	}
+
+	// Estimate
+	estimate := &cobra.Command{
+		Use:     "estimate",
+		Short:   "Estimate the cost of the resources in a manifest",
+		Long:    estimateLong,
+		Example: estimateExample,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			file, errFi := cmd.Flags().GetString("file")
+			if errFi != nil {
+				return fmt.Errorf("error parsing flag 'file' for cost estimate : %v", errFi)
+			}
+
+			m, err := NewManifestFromFile(file)
+			if err != nil {
+				return err
+			}
+
+			est, err := m.Estimate(NewEstimator(o.Base))
+			if err != nil {
+				return fmt.Errorf("error estimating cost : %v", err)
+			}
+
+			o.Base.Printer.Display(&EstimatePrinter{Estimate: est}, nil)
+
+			return nil
+		},
+	}
+
+	estimate.Flags().StringP("file", "f", "", "path to a YAML or JSON manifest of the resources to estimate")
+	if err := estimate.MarkFlagRequired("file"); err != nil {
+		fmt.Printf("error marking cost estimate 'file' flag required: %v", err)
+		os.Exit(1)
+	}
+
+	cmd.AddCommand(
+		estimate,
+	)
+
+	return cmd
+}
+
+type options struct {
+	Base *cli.Base
+}
+
+// Manifest is the document of resources used by cost estimate
+type Manifest struct {
+	Instances     []InstanceSpec      `yaml:"instances"`
+	BareMetal     []BareMetalSpec     `yaml:"bare_metal"`
+	BlockStorage  []BlockStorageSpec  `yaml:"block_storage"`
+	Kubernetes    []KubernetesSpec    `yaml:"kubernetes"`
+	Databases     []DatabaseSpec      `yaml:"databases"`
+	ObjectStorage []ObjectStorageSpec `yaml:"object_storage"`
+}
+
+// InstanceSpec is an instance of a manifest
+type InstanceSpec struct {
+	Plan        string `yaml:"plan"`
+	Region      string `yaml:"region,omitempty"`
+	Quantity    int    `yaml:"quantity,omitempty"`
+	AutoBackups bool   `yaml:"auto_backups,omitempty"`
+}
+
+// BareMetalSpec is a bare metal server of a manifest
+type BareMetalSpec struct {
+	Plan     string `yaml:"plan"`
+	Region   string `yaml:"region,omitempty"`
+	Quantity int    `yaml:"quantity,omitempty"`
+}
+
+// BlockStorageSpec is a block storage of a manifest
+type BlockStorageSpec struct {
+	SizeGB    int    `yaml:"size_gb"`
+	BlockType string `yaml:"block_type,omitempty"`
+	Region    string `yaml:"region,omitempty"`
+	Quantity  int    `yaml:"quantity,omitempty"`
+}
+
+// KubernetesSpec is a Kubernetes cluster of a manifest
+type KubernetesSpec struct {
+	Region    string         `yaml:"region,omitempty"`
+	NodePools []NodePoolSpec `yaml:"node_pools"`
+}
+
+// NodePoolSpec is a node pool of a Kubernetes cluster of a manifest
+type NodePoolSpec struct {
+	Label    string `yaml:"label,omitempty"`
+	Plan     string `yaml:"plan"`
+	Quantity int    `yaml:"quantity"`
+}
+
+// DatabaseSpec is a managed database of a manifest
+type DatabaseSpec struct {
+	Plan     string `yaml:"plan"`
+	Region   string `yaml:"region,omitempty"`
+	Quantity int    `yaml:"quantity,omitempty"`
+}
+
+// ObjectStorageSpec is an object storage subscription of a manifest
+type ObjectStorageSpec struct {
+	Tier     string `yaml:"tier"`
+	Quantity int    `yaml:"quantity,omitempty"`
+}
+
+// NewManifestFromFile reads in a YAML or JSON manifest
+func NewManifestFromFile(path string) (*Manifest, error) {
+	fd, err := os.ReadFile(filepath.Clean(path))
+	if err != nil {
+		return nil, fmt.Errorf("error reading manifest file : %v", err)
+	}
+
+	dec := yaml.NewDecoder(bytes.NewReader(fd))
+	dec.KnownFields(true)
+
+	m := &Manifest{}
+	if err := dec.Decode(m); err != nil {
+		return nil, fmt.Errorf("error parsing manifest file : %v", err)
+	}
+
+	if err := m.validate(); err != nil {
+		return nil, err
+	}
+
+	return m, nil
+}
+
+func (m *Manifest) validate() error { //nolint:gocyclo
+	var errs []string
+	for i := range m.Instances {
+		if m.Instances[i].Plan == "" {
+			errs = append(errs, fmt.Sprintf("instances[%d] requires a plan", i))
+		}
+	}
+
+	for i := range m.BareMetal {
+		if m.BareMetal[i].Plan == "" {
+			errs = append(errs, fmt.Sprintf("bare_metal[%d] requires a plan", i))
+		}
+	}
+
+	for i := range m.BlockStorage {
+		if m.BlockStorage[i].SizeGB <= 0 {
+			errs = append(errs, fmt.Sprintf("block_storage[%d] requires a positive size_gb", i))
+		}
+	}
+
+	for i := range m.Kubernetes {
+		if len(m.Kubernetes[i].NodePools) == 0 {
+			errs = append(errs, fmt.Sprintf("kubernetes[%d] requires at least one node pool", i))
+		}
+
+		for j := range m.Kubernetes[i].NodePools {
+			if m.Kubernetes[i].NodePools[j].Plan == "" || m.Kubernetes[i].NodePools[j].Quantity <= 0 {
+				errs = append(errs, fmt.Sprintf("kubernetes[%d].node_pools[%d] requires a plan and a positive quantity", i, j))
+			}
+		}
+	}
+
+	for i := range m.Databases {
+		if m.Databases[i].Plan == "" {
+			errs = append(errs, fmt.Sprintf("databases[%d] requires a plan", i))
+		}
+	}
+
+	for i := range m.ObjectStorage {
+		if m.ObjectStorage[i].Tier == "" {
+			errs = append(errs, fmt.Sprintf("object_storage[%d] requires a tier", i))
+		}
+	}
+
+	if len(errs) > 0 {
+		return fmt.Errorf("invalid manifest file :\n  %s", strings.Join(errs, "\n  "))
+	}
+
+	return nil
+}
+
+// Estimate prices every resource of the manifest
+func (m *Manifest) Estimate(e *Estimator) (*Estimate, error) { //nolint:gocyclo
+	est := NewEstimate()
+	for i := range m.Instances {
+		in := &m.Instances[i]
+		items, err := e.Instance(in.Plan, in.Region, in.Quantity, in.AutoBackups)
+		if err != nil {
+			return nil, fmt.Errorf("instances[%d] : %v", i, err)
+		}
+		for j := range items {
+			est.Add(items[j])
+		}
+	}
+
+	for i := range m.BareMetal {
+		item, err := e.BareMetal(m.BareMetal[i].Plan, m.BareMetal[i].Region, m.BareMetal[i].Quantity)
+		if err != nil {
+			return nil, fmt.Errorf("bare_metal[%d] : %v", i, err)
+		}
+		est.Add(item)
+	}
+
+	for i := range m.BlockStorage {
+		bs := &m.BlockStorage[i]
+		item, err := e.BlockStorage(bs.SizeGB, bs.BlockType, bs.Region, bs.Quantity)
+		if err != nil {
+			return nil, fmt.Errorf("block_storage[%d] : %v", i, err)
+		}
+		est.Add(item)
+	}
+
+	for i := range m.Kubernetes {
+		var pools []govultr.NodePoolReq
+		for j := range m.Kubernetes[i].NodePools {
+			np := &m.Kubernetes[i].NodePools[j]
+			pools = append(pools, govultr.NodePoolReq{Label: np.Label, Plan: np.Plan, NodeQuantity: np.Quantity})
+		}
+
+		items, err := e.Kubernetes(m.Kubernetes[i].Region, pools)
+		if err != nil {
+			return nil, fmt.Errorf("kubernetes[%d] : %v", i, err)
+		}
+		for j := range items {
+			est.Add(items[j])
+		}
+	}
+
+	for i := range m.Databases {
+		item, err := e.Database(m.Databases[i].Plan, m.Databases[i].Region, m.Databases[i].Quantity)
+		if err != nil {
+			return nil, fmt.Errorf("databases[%d] : %v", i, err)
+		}
+		est.Add(item)
+	}
+
+	for i := range m.ObjectStorage {
+		item, err := e.ObjectStorage(m.ObjectStorage[i].Tier, m.ObjectStorage[i].Quantity)
+		if err != nil {
+			return nil, fmt.Errorf("object_storage[%d] : %v", i, err)
+		}
+		est.Add(item)
+	}
+
+	return est, nil
+}
@@ -0,0 +1,381 @@
+// Package cost provides the cost estimation of resources
+package cost
+
+import (
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/spf13/cobra"
+	"github.com/vultr/govultr/v3"
+	"github.com/vultr/vultr-cli/v3/cmd/utils"
+	"github.com/vultr/vultr-cli/v3/pkg/cli"
+)
+
+const (
+	// HoursPerMonth is the number of hours after which hourly billing reaches
+	// the monthly price
+	HoursPerMonth float64 = 672
+
+	// backupsRate is the price of automatic backups relative to the plan
+	backupsRate float64 = 0.2
+
+	// Block storage prices per GB per month.  The API does not provide block
+	// storage prices so the published prices are used.
+	blockHighPerfGBPrice   float64 = 0.10
+	blockStorageOptGBPrice float64 = 0.025
+
+	blockTypeHighPerf   string = "high_perf"
+	blockTypeStorageOpt string = "storage_opt"
+
+	TypeInstance      string = "instance"
+	TypeBackups       string = "backups"
+	TypeBareMetal     string = "bare-metal"
+	TypeBlockStorage  string = "block-storage"
+	TypeKubernetes    string = "kubernetes"
+	TypeDatabase      string = "database"
+	TypeObjectStorage string = "object-storage"
+)
+
+// Item is the estimated cost of a single line of an estimate
+type Item struct {
+	Type        string  `json:"type"`
+	Name        string  `json:"name"`
+	Region      string  `json:"region,omitempty"`
+	Quantity    int     `json:"quantity"`
+	MonthlyCost float64 `json:"monthly_cost"`
+	HourlyCost  float64 `json:"hourly_cost"`
+	Approximate bool    `json:"approximate"`
+}
+
+// Estimate is the estimated cost of a set of resources.  Approximate is true
+// when an item is priced from published prices rather than the API.
+type Estimate struct {
+	Items       []Item  `json:"items"`
+	MonthlyCost float64 `json:"monthly_cost"`
+	HourlyCost  float64 `json:"hourly_cost"`
+	Approximate bool    `json:"approximate"`
+}
+
+// NewEstimate totals the items into an estimate
+func NewEstimate(items ...Item) *Estimate {
+	e := &Estimate{Items: []Item{}}
+	for i := range items {
+		e.Add(items[i])
+	}
+	return e
+}
+
+// Add adds the item to the estimate
+func (e *Estimate) Add(item Item) {
+	e.Items = append(e.Items, item)
+	e.MonthlyCost += item.MonthlyCost
+	e.HourlyCost += item.HourlyCost
+	e.Approximate = e.Approximate || item.Approximate
+}
+
+// newItem returns the item of the quantity of resources at the monthly price
+func newItem(typ, name, region string, quantity int, monthly float64) Item {
+	if quantity < 1 {
+		quantity = 1
+	}
+
+	total := monthly * float64(quantity)
+	return Item{
+		Type:        typ,
+		Name:        name,
+		Region:      region,
+		Quantity:    quantity,
+		MonthlyCost: total,
+		HourlyCost:  total / HoursPerMonth,
+	}
+}
+
+// AddFlag adds the --estimate flag to a create command
+func AddFlag(cmd *cobra.Command) {
+	cmd.Flags().Bool(
+		"estimate",
+		false,
+		"(optional) display the estimated monthly and hourly cost without creating anything",
+	)
+}
+
+// Estimator prices resources from the plan and tier lists, which are each
+// retrieved once when first needed.  It is safe for concurrent use.
+type Estimator struct {
+	base *cli.Base
+
+	plansOnce sync.Once
+	plans     map[string]*govultr.Plan
+	plansErr  error
+
+	bareMetalOnce sync.Once
+	bareMetal     map[string]*govultr.BareMetalPlan
+	bareMetalErr  error
+
+	databasesOnce sync.Once
+	databases     map[string]*govultr.DatabasePlan
+	databasesErr  error
+
+	tiersOnce sync.Once
+	tiers     []govultr.ObjectStorageTier
+	tiersErr  error
+}
+
+// NewEstimator returns an estimator using the client of the base
+func NewEstimator(base *cli.Base) *Estimator {
+	return &Estimator{base: base}
+}
+
+func (e *Estimator) loadPlans() error {
+	e.plansOnce.Do(func() {
+		plans, err := utils.ListAll(func(opts *govultr.ListOptions) ([]govultr.Plan, *govultr.Meta, error) {
+			plans, meta, _, err := e.base.Client.Plan.List(e.base.Context, "all", opts)
+			return plans, meta, err
+		})
+		if err != nil {
+			e.plansErr = fmt.Errorf("error retrieving plans : %v", err)
+			return
+		}
+
+		e.plans = map[string]*govultr.Plan{}
+		for i := range plans {
+			e.plans[plans[i].ID] = &plans[i]
+		}
+	})
+	return e.plansErr
+}
+
+func (e *Estimator) loadBareMetal() error {
+	e.bareMetalOnce.Do(func() {
+		plans, err := utils.ListAll(func(opts *govultr.ListOptions) ([]govultr.BareMetalPlan, *govultr.Meta, error) {
+			plans, meta, _, err := e.base.Client.Plan.ListBareMetal(e.base.Context, opts)
+			return plans, meta, err
+		})
+		if err != nil {
+			e.bareMetalErr = fmt.Errorf("error retrieving bare metal plans : %v", err)
+			return
+		}
+
+		e.bareMetal = map[string]*govultr.BareMetalPlan{}
+		for i := range plans {
+			e.bareMetal[plans[i].ID] = &plans[i]
+		}
+	})
+	return e.bareMetalErr
+}
+
+func (e *Estimator) loadDatabases() error {
+	e.databasesOnce.Do(func() {
+		e.databases = map[string]*govultr.DatabasePlan{}
+		plans, _, _, err := e.base.Client.Database.ListPlans(e.base.Context, &govultr.DBPlanListOptions{})
+		if err != nil {
+			e.databasesErr = fmt.Errorf("error retrieving database plans : %v", err)
+			return
+		}
+
+		for i := range plans {
+			e.databases[plans[i].ID] = &plans[i]
+		}
+	})
+	return e.databasesErr
+}
+
+func (e *Estimator) loadTiers() error {
+	e.tiersOnce.Do(func() {
+		e.tiers, _, e.tiersErr = e.base.Client.ObjectStorage.ListTiers(e.base.Context)
+		if e.tiersErr != nil {
+			e.tiersErr = fmt.Errorf("error retrieving object storage tiers : %v", e.tiersErr)
+		}
+	})
+	return e.tiersErr
+}
+
+// checkLocation returns an error when a region is given which the plan is not
+// available in
+func checkLocation(plan, region string, locations []string) error {
+	if region == "" || slices.Contains(locations, region) {
+		return nil
+	}
+	return fmt.Errorf("plan %s is not available in region %s", plan, region)
+}
+
+// PlanPrice returns the monthly price of an instance plan and whether the
+// plan was found
+func (e *Estimator) PlanPrice(id string) (float64, bool, error) {
+	if err := e.loadPlans(); err != nil {
+		return 0, false, err
+	}
+
+	plan, ok := e.plans[id]
+	if !ok {
+		return 0, false, nil
+	}
+	return float64(plan.MonthlyCost), true, nil
+}
+
+// BareMetalPrice returns the monthly price of a bare metal plan and whether
+// the plan was found
+func (e *Estimator) BareMetalPrice(id string) (float64, bool, error) {
+	if err := e.loadBareMetal(); err != nil {
+		return 0, false, err
+	}
+
+	plan, ok := e.bareMetal[id]
+	if !ok {
+		return 0, false, nil
+	}
+	return float64(plan.MonthlyCost), true, nil
+}
+
+// DatabasePrice returns the monthly price of a managed database plan and
+// whether the plan was found
+func (e *Estimator) DatabasePrice(id string) (float64, bool, error) {
+	if err := e.loadDatabases(); err != nil {
+		return 0, false, err
+	}
+
+	plan, ok := e.databases[id]
+	if !ok {
+		return 0, false, nil
+	}
+	return float64(plan.MonthlyCost), true, nil
+}
+
+// Instance estimates instances of the plan along with their automatic backups
+func (e *Estimator) Instance(plan, region string, quantity int, backups bool) ([]Item, error) {
+	if err := e.loadPlans(); err != nil {
+		return nil, err
+	}
+
+	p, ok := e.plans[plan]
+	if !ok {
+		return nil, fmt.Errorf("plan %s not found", plan)
+	}
+
+	if err := checkLocation(plan, region, p.Locations); err != nil {
+		return nil, err
+	}
+
+	items := []Item{newItem(TypeInstance, plan, region, quantity, float64(p.MonthlyCost))}
+	if backups {
+		item := newItem(TypeBackups, plan, region, quantity, float64(p.MonthlyCost)*backupsRate)
+		item.Approximate = true
+		items = append(items, item)
+	}
+
+	return items, nil
+}
+
+// BareMetal estimates bare metal servers of the plan
+func (e *Estimator) BareMetal(plan, region string, quantity int) (Item, error) {
+	if err := e.loadBareMetal(); err != nil {
+		return Item{}, err
+	}
+
+	p, ok := e.bareMetal[plan]
+	if !ok {
+		return Item{}, fmt.Errorf("bare metal plan %s not found", plan)
+	}
+
+	if err := checkLocation(plan, region, p.Locations); err != nil {
+		return Item{}, err
+	}
+
+	return newItem(TypeBareMetal, plan, region, quantity, float64(p.MonthlyCost)), nil
+}
+
+// BlockStorage estimates block storage of the size and type, which defaults
+// to high_perf as on creation
+func (e *Estimator) BlockStorage(sizeGB int, blockType, region string, quantity int) (Item, error) {
+	if sizeGB <= 0 {
+		return Item{}, fmt.Errorf("invalid block storage size %d", sizeGB)
+	}
+
+	var price float64
+	switch blockType {
+	case "", blockTypeHighPerf:
+		blockType = blockTypeHighPerf
+		price = blockHighPerfGBPrice
+	case blockTypeStorageOpt:
+		price = blockStorageOptGBPrice
+	default:
+		return Item{}, fmt.Errorf(
+			"invalid block type %s, must be one of %s, %s",
+			blockType,
+			blockTypeHighPerf,
+			blockTypeStorageOpt,
+		)
+	}
+
+	item := newItem(TypeBlockStorage, fmt.Sprintf("%s %d GB", blockType, sizeGB), region, quantity, price*float64(sizeGB))
+	item.Approximate = true
+
+	return item, nil
+}
+
+// Kubernetes estimates the node pools of a cluster.  The control plane is not
+// included.
+func (e *Estimator) Kubernetes(region string, pools []govultr.NodePoolReq) ([]Item, error) {
+	if err := e.loadPlans(); err != nil {
+		return nil, err
+	}
+
+	var items []Item
+	for i := range pools {
+		p, ok := e.plans[pools[i].Plan]
+		if !ok {
+			return nil, fmt.Errorf("node pool %s plan %s not found", pools[i].Label, pools[i].Plan)
+		}
+
+		if err := checkLocation(pools[i].Plan, region, p.Locations); err != nil {
+			return nil, fmt.Errorf("node pool %s : %v", pools[i].Label, err)
+		}
+
+		name := pools[i].Plan
+		if pools[i].Label != "" {
+			name = fmt.Sprintf("%s (%s)", pools[i].Label, pools[i].Plan)
+		}
+		items = append(items, newItem(TypeKubernetes, name, region, pools[i].NodeQuantity, float64(p.MonthlyCost)))
+	}
+
+	return items, nil
+}
+
+// Database estimates managed databases of the plan
+func (e *Estimator) Database(plan, region string, quantity int) (Item, error) {
+	if err := e.loadDatabases(); err != nil {
+		return Item{}, err
+	}
+
+	p, ok := e.databases[plan]
+	if !ok {
+		return Item{}, fmt.Errorf("database plan %s not found", plan)
+	}
+
+	if err := checkLocation(plan, region, p.Locations); err != nil {
+		return Item{}, err
+	}
+
+	return newItem(TypeDatabase, plan, region, quantity, float64(p.MonthlyCost)), nil
+}
+
+// ObjectStorage estimates object storage subscriptions of the tier, given by
+// its ID or slug.  Usage beyond the tier allowance is not included.
+func (e *Estimator) ObjectStorage(tier string, quantity int) (Item, error) {
+	if err := e.loadTiers(); err != nil {
+		return Item{}, err
+	}
+
+	var slugs []string
+	for i := range e.tiers {
+		if strconv.Itoa(e.tiers[i].ID) == tier || strings.EqualFold(e.tiers[i].Slug, tier) {
+			return newItem(TypeObjectStorage, e.tiers[i].Slug, "", quantity, float64(e.tiers[i].Price)), nil
+		}
+		slugs = append(slugs, e.tiers[i].Slug)
+	}
+
+	return Item{}, fmt.Errorf("object storage tier %s not found, must be one of %s", tier, strings.Join(slugs, ", "))
+}
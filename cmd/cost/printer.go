@@ -0,0 +1,97 @@
+package cost
+
+import (
+	"strconv"
+
+	"github.com/vultr/vultr-cli/v3/cmd/printer"
+	"github.com/vultr/vultr-cli/v3/cmd/utils"
+)
+
+const (
+	// hourlyPrecision is the precision of hourly costs, which are fractions of a cent
+	hourlyPrecision int = 4
+
+	// approximateMark prefixes the costs priced from published prices
+	approximateMark string = "~"
+)
+
+// EstimatePrinter ...
+type EstimatePrinter struct {
+	Estimate *Estimate `json:"estimate"`
+}
+
+// JSON ...
+func (e *EstimatePrinter) JSON() []byte {
+	return printer.MarshalObject(e, "json")
+}
+
+// YAML ...
+func (e *EstimatePrinter) YAML() []byte {
+	return printer.MarshalObject(e, "yaml")
+}
+
+// Columns ...
+func (e *EstimatePrinter) Columns() [][]string {
+	return [][]string{0: {
+		"TYPE",
+		"NAME",
+		"REGION",
+		"QUANTITY",
+		"MONTHLY COST",
+		"HOURLY COST",
+	}}
+}
+
+// Data ...
+func (e *EstimatePrinter) Data() [][]string {
+	if len(e.Estimate.Items) == 0 {
+		return [][]string{0: {"---", "---", "---", "---", "---", "---"}}
+	}
+
+	var data [][]string
+	for i := range e.Estimate.Items {
+		item := &e.Estimate.Items[i]
+		data = append(data, []string{
+			item.Type,
+			item.Name,
+			item.Region,
+			strconv.Itoa(item.Quantity),
+			markApproximate(formatCost(item.MonthlyCost, utils.FloatPrecision), item.Approximate),
+			markApproximate(formatCost(item.HourlyCost, hourlyPrecision), item.Approximate),
+		})
+	}
+
+	return data
+}
+
+// Paging ...
+func (e *EstimatePrinter) Paging() [][]string {
+	paging := [][]string{
+		0: {"======================================"},
+		1: {
+			"ESTIMATED MONTHLY COST",
+			markApproximate(formatCost(e.Estimate.MonthlyCost, utils.FloatPrecision), e.Estimate.Approximate),
+		},
+		2: {
+			"ESTIMATED HOURLY COST",
+			markApproximate(formatCost(e.Estimate.HourlyCost, hourlyPrecision), e.Estimate.Approximate),
+		},
+	}
+
+	if e.Estimate.Approximate {
+		paging = append(paging, []string{approximateMark + " approximate, the API does not provide these prices"})
+	}
+
+	return paging
+}
+
+func markApproximate(cost string, approximate bool) string {
+	if approximate {
+		return approximateMark + cost
+	}
+	return cost
+}
+
+func formatCost(cost float64, precision int) string {
+	return "$" + strconv.FormatFloat(cost, 'f', precision, 64)
+}
@@ -0,0 +1,372 @@
+package database
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"os"
+	"path/filepath"
+	"reflect"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/vultr/govultr/v3"
+	"gopkg.in/yaml.v3"
+)
+
+const (
+	optionTypeInt   string = "int"
+	optionTypeFloat string = "float"
+	optionTypeBool  string = "bool"
+	optionTypeEnum  string = "enum"
+
+	optionActionChange    string = "change"
+	optionActionUnchanged string = "unchanged"
+	optionActionKeep      string = "keep"
+
+	// optionNotConfigured is displayed for options which are not configured
+	// since the API does not report their default values
+	optionNotConfigured string = "(not configured)"
+)
+
+// errNoDesiredOptions is returned by diff when no options are given
+var errNoDesiredOptions = errors.New("please provide the desired options with --file or --set")
+
+// AdvancedOptionChange is an advanced option compared with its desired value
+type AdvancedOptionChange struct {
+	Name    string `json:"name"`
+	Current string `json:"current"`
+	Desired string `json:"desired"`
+	Allowed string `json:"allowed"`
+	Action  string `json:"action"`
+}
+
+// addAdvancedOptionFlags adds the flags reading advanced options from a file
+// or from key=value pairs
+func addAdvancedOptionFlags(cmd *cobra.Command) {
+	cmd.Flags().StringP("file", "f", "", "(optional) path to a YAML or JSON file of advanced option names and values")
+	cmd.Flags().StringArray(
+		"set",
+		nil,
+		"(optional) set an advanced option as name=value. Can be repeated and takes precedence over the file",
+	)
+}
+
+// desiredAdvancedOptions reads the advanced options given with the --file and
+// --set flags.  A nil map is returned when neither flag is used.
+func desiredAdvancedOptions(cmd *cobra.Command, name string) (map[string]interface{}, error) {
+	file, errFi := cmd.Flags().GetString("file")
+	if errFi != nil {
+		return nil, fmt.Errorf("error parsing flag 'file' for %s : %v", name, errFi)
+	}
+
+	set, errSe := cmd.Flags().GetStringArray("set")
+	if errSe != nil {
+		return nil, fmt.Errorf("error parsing flag 'set' for %s : %v", name, errSe)
+	}
+
+	if file == "" && len(set) == 0 {
+		return nil, nil
+	}
+
+	desired := map[string]interface{}{}
+	if file != "" {
+		fd, err := os.ReadFile(filepath.Clean(file))
+		if err != nil {
+			return nil, fmt.Errorf("error reading advanced options file : %v", err)
+		}
+
+		if err := yaml.NewDecoder(bytes.NewReader(fd)).Decode(&desired); err != nil {
+			return nil, fmt.Errorf("error parsing advanced options file : %v", err)
+		}
+	}
+
+	for i := range set {
+		k, v, ok := strings.Cut(set[i], "=")
+		if !ok || k == "" {
+			return nil, fmt.Errorf("invalid --set value %q, must be in the form name=value", set[i])
+		}
+
+		// Parse the value as YAML to type numbers and booleans
+		var value interface{}
+		if err := yaml.Unmarshal([]byte(v), &value); err != nil || value == nil {
+			value = v
+		}
+		desired[k] = value
+	}
+
+	return desired, nil
+}
+
+// validateAdvancedOptions checks the desired options against the available
+// option metadata and returns them converted to the option types
+func validateAdvancedOptions(
+	desired map[string]interface{},
+	avail []govultr.AvailableOption,
+) (map[string]interface{}, error) {
+	options := map[string]*govultr.AvailableOption{}
+	for i := range avail {
+		options[avail[i].Name] = &avail[i]
+	}
+
+	var errs []string
+	values := map[string]interface{}{}
+	for _, k := range sortedKeys(desired) {
+		opt, ok := options[k]
+		if !ok {
+			errs = append(errs, fmt.Sprintf("%s is not an available option", k))
+			continue
+		}
+
+		v, err := optionValue(opt, desired[k])
+		if err != nil {
+			errs = append(errs, fmt.Sprintf("%s %v", k, err))
+			continue
+		}
+		values[k] = v
+	}
+
+	if len(errs) > 0 {
+		return nil, fmt.Errorf("invalid advanced options :\n  %s", strings.Join(errs, "\n  "))
+	}
+
+	return values, nil
+}
+
+// optionValue converts the value to the type of the option and checks it
+// against the option's bounds or enumerals
+func optionValue(opt *govultr.AvailableOption, value interface{}) (interface{}, error) { //nolint:gocyclo
+	switch opt.Type {
+	case optionTypeInt, optionTypeFloat:
+		// integers are kept as decoded since large YAML integers are int64
+		// or uint64 and do not fit a float64 exactly
+		var n float64
+		var integer interface{}
+		rv := reflect.ValueOf(value)
+		switch rv.Kind() {
+		case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+			n, integer = float64(rv.Int()), rv.Int()
+		case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+			n, integer = float64(rv.Uint()), rv.Uint()
+		case reflect.Float32, reflect.Float64:
+			n = rv.Float()
+		default:
+			return nil, fmt.Errorf("must be a number, got %v", value)
+		}
+
+		if opt.Type == optionTypeInt && integer == nil {
+			if n != math.Trunc(n) {
+				return nil, fmt.Errorf("must be an integer, got %v", value)
+			}
+			integer = int64(n)
+		}
+
+		if opt.Type == optionTypeInt && slices.Contains(opt.AltValues, int(n)) {
+			return integer, nil
+		}
+
+		if opt.MinValue != nil && n < float64(*opt.MinValue) || opt.MaxValue != nil && n > float64(*opt.MaxValue) {
+			return nil, fmt.Errorf("must be within %s, got %v", optionAllowed(opt), value)
+		}
+
+		if opt.Type == optionTypeInt {
+			return integer, nil
+		}
+		return n, nil
+	case optionTypeBool, "boolean":
+		b, ok := value.(bool)
+		if !ok {
+			return nil, fmt.Errorf("must be true or false, got %v", value)
+		}
+		return b, nil
+	case optionTypeEnum:
+		s := fmt.Sprint(value)
+		if !slices.Contains(opt.Enumerals, s) {
+			return nil, fmt.Errorf("must be one of %s, got %s", strings.Join(opt.Enumerals, ", "), s)
+		}
+		return s, nil
+	default:
+		return fmt.Sprint(value), nil
+	}
+}
+
+// advancedOptionsRequest validates the desired options and converts them into
+// the request type.  Options the request type does not know of or would omit
+// as zero values are an error rather than being silently dropped.
+func advancedOptionsRequest[T any](desired map[string]interface{}, avail []govultr.AvailableOption) (*T, error) {
+	values, err := validateAdvancedOptions(desired, avail)
+	if err != nil {
+		return nil, err
+	}
+
+	var errs []string
+	supported := map[string]interface{}{}
+	for _, k := range sortedKeys(values) {
+		b, err := json.Marshal(map[string]interface{}{k: values[k]})
+		if err != nil {
+			return nil, fmt.Errorf("error marshaling advanced options : %v", err)
+		}
+
+		dec := json.NewDecoder(bytes.NewReader(b))
+		dec.DisallowUnknownFields()
+		if err := dec.Decode(new(T)); err != nil {
+			errs = append(errs, fmt.Sprintf("%s is not supported by this version of vultr-cli", k))
+			continue
+		}
+		supported[k] = values[k]
+	}
+
+	b, err := json.Marshal(supported)
+	if err != nil {
+		return nil, fmt.Errorf("error marshaling advanced options : %v", err)
+	}
+
+	req := new(T)
+	if err := json.Unmarshal(b, req); err != nil {
+		return nil, fmt.Errorf("error converting advanced options : %v", err)
+	}
+
+	sent, err := optionMap(req)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, k := range sortedKeys(supported) {
+		if _, ok := sent[k]; !ok {
+			errs = append(errs, fmt.Sprintf("%s cannot be set to %v through the API", k, supported[k]))
+		}
+	}
+
+	if len(errs) > 0 {
+		return nil, fmt.Errorf("invalid advanced options :\n  %s", strings.Join(errs, "\n  "))
+	}
+
+	return req, nil
+}
+
+// diffAdvancedOptions compares the configured options with the desired ones.
+// Every configured or desired option is listed.
+func diffAdvancedOptions(
+	current interface{},
+	desired map[string]interface{},
+	avail []govultr.AvailableOption,
+) ([]AdvancedOptionChange, error) {
+	values, err := validateAdvancedOptions(desired, avail)
+	if err != nil {
+		return nil, err
+	}
+
+	configured, err := optionMap(current)
+	if err != nil {
+		return nil, err
+	}
+
+	names := map[string]bool{}
+	for k := range configured {
+		names[k] = true
+	}
+	for k := range values {
+		names[k] = true
+	}
+
+	var changes []AdvancedOptionChange
+	for _, k := range sortedKeys(names) {
+		change := AdvancedOptionChange{Name: k, Current: optionNotConfigured, Desired: "---", Action: optionActionKeep}
+
+		for i := range avail {
+			if avail[i].Name == k {
+				change.Allowed = optionAllowed(&avail[i])
+			}
+		}
+
+		if v, ok := configured[k]; ok {
+			change.Current = formatOptionValue(v)
+		}
+
+		if v, ok := values[k]; ok {
+			change.Desired = formatOptionValue(v)
+			change.Action = optionActionChange
+			if change.Desired == change.Current {
+				change.Action = optionActionUnchanged
+			}
+		}
+
+		changes = append(changes, change)
+	}
+
+	return changes, nil
+}
+
+// optionMap returns the options set in the API type by their names
+func optionMap(options interface{}) (map[string]interface{}, error) {
+	m := map[string]interface{}{}
+	if options == nil {
+		return m, nil
+	}
+
+	b, err := json.Marshal(options)
+	if err != nil {
+		return nil, fmt.Errorf("error marshaling advanced options : %v", err)
+	}
+
+	if err := json.Unmarshal(b, &m); err != nil {
+		return nil, fmt.Errorf("error reading advanced options : %v", err)
+	}
+
+	return m, nil
+}
+
+// optionAllowed describes the values allowed for the option
+func optionAllowed(opt *govultr.AvailableOption) string {
+	var allowed string
+	switch {
+	case len(opt.Enumerals) > 0:
+		allowed = strings.Join(opt.Enumerals, ", ")
+	case opt.MinValue != nil && opt.MaxValue != nil:
+		allowed = fmt.Sprintf("%s..%s", formatOptionValue(*opt.MinValue), formatOptionValue(*opt.MaxValue))
+	case opt.Type == optionTypeBool || opt.Type == "boolean":
+		allowed = "true, false"
+	default:
+		allowed = opt.Type
+	}
+
+	if len(opt.AltValues) > 0 {
+		var alt []string
+		for i := range opt.AltValues {
+			alt = append(alt, strconv.Itoa(opt.AltValues[i]))
+		}
+		allowed += " or " + strings.Join(alt, ", ")
+	}
+
+	if opt.Units != "" {
+		allowed += " " + opt.Units
+	}
+
+	return allowed
+}
+
+// formatOptionValue formats an option value for comparison and display
+func formatOptionValue(v interface{}) string {
+	switch n := v.(type) {
+	case float32:
+		return strconv.FormatFloat(float64(n), 'f', -1, 32)
+	case float64:
+		return strconv.FormatFloat(n, 'f', -1, 64)
+	default:
+		return fmt.Sprint(v)
+	}
+}
+
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
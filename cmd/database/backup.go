@@ -0,0 +1,120 @@
+package database
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/vultr/govultr/v3"
+)
+
+const (
+	backupDateLayout string = "2006-01-02"
+	backupTimeLayout string = "15:04:05"
+
+	restoreTypePITR string = "pitr"
+
+	dbStatusRunning string = "Running"
+
+	restoreDefaultTimeout = 30 * time.Minute
+	restorePollInterval   = 15 * time.Second
+)
+
+// PointInTimeOptions selects the recovery target of a restore or fork and
+// whether to wait for the new database
+type PointInTimeOptions struct {
+	At      string
+	Ago     time.Duration
+	Wait    bool
+	Timeout time.Duration
+}
+
+// parseBackupTime returns the UTC time of a backup
+func parseBackupTime(b *govultr.DatabaseBackup) (time.Time, error) {
+	t, err := time.Parse(backupDateLayout+" "+backupTimeLayout, b.Date+" "+b.Time)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid backup date %s and time %s", b.Date, b.Time)
+	}
+	return t, nil
+}
+
+// restoreTarget returns the point in time from either an absolute RFC 3339
+// time or a duration before now
+func restoreTarget(at string, ago time.Duration, now time.Time) (time.Time, error) {
+	if at != "" {
+		t, err := time.Parse(time.RFC3339, at)
+		if err != nil {
+			return time.Time{}, fmt.Errorf("invalid time %s, must be in the RFC 3339 format such as 2026-10-01T12:00:00Z", at)
+		}
+		return t.UTC(), nil
+	}
+
+	if ago <= 0 {
+		return time.Time{}, errors.New("the duration must be positive")
+	}
+
+	return now.Add(-ago).UTC().Truncate(time.Second), nil
+}
+
+// checkRestoreWindow checks that the target is between the oldest backup and
+// now, which is the window a point-in-time recovery can be made from
+func checkRestoreWindow(target time.Time, backups *govultr.DatabaseBackups, now time.Time) error {
+	oldest, err := parseBackupTime(&backups.OldestBackup)
+	if err != nil {
+		return fmt.Errorf("unable to determine the backup window : %v", err)
+	}
+
+	if target.Before(oldest) || target.After(now) {
+		return fmt.Errorf(
+			"%s is outside of the backup window, which is from %s to now",
+			target.Format(time.RFC3339),
+			oldest.Format(time.RFC3339),
+		)
+	}
+
+	return nil
+}
+
+// pointInTime resolves the --at or --ago target, validated against the
+// backup window of the database, into the date and time of the API request
+func (o *options) pointInTime(at string, ago time.Duration) (date, clock string, err error) {
+	now := time.Now()
+	target, err := restoreTarget(at, ago, now)
+	if err != nil {
+		return "", "", err
+	}
+
+	backups, err := o.getBackup()
+	if err != nil {
+		return "", "", fmt.Errorf("error retrieving database backups : %v", err)
+	}
+
+	if err := checkRestoreWindow(target, backups, now); err != nil {
+		return "", "", err
+	}
+
+	return target.Format(backupDateLayout), target.Format(backupTimeLayout), nil
+}
+
+// waitRunning polls the database until it is running
+func (o *options) waitRunning(id string, timeout time.Duration) (*govultr.Database, error) {
+	deadline := time.Now().Add(timeout)
+	for {
+		db, _, err := o.Base.Client.Database.Get(o.Base.Context, id)
+		if err != nil {
+			return nil, fmt.Errorf("error retrieving database %s : %v", id, err)
+		}
+
+		if db.Status == dbStatusRunning {
+			return db, nil
+		}
+
+		if time.Now().After(deadline) {
+			return nil, fmt.Errorf("timed out after %s waiting for database %s, status: %s", timeout, id, db.Status)
+		}
+
+		fmt.Fprintf(os.Stderr, "waiting for database %s, status: %s\n", id, db.Status)
+		time.Sleep(restorePollInterval)
+	}
+}
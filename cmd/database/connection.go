@@ -0,0 +1,336 @@
+package database
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"net/url"
+	"os"
+	"os/exec"
+	"strings"
+
+	"github.com/vultr/govultr/v3"
+)
+
+const (
+	engineMySQL  string = "mysql"
+	enginePG     string = "pg"
+	engineValkey string = "valkey"
+	engineKafka  string = "kafka"
+
+	formatURI   string = "uri"
+	formatJDBC  string = "jdbc"
+	formatLibpq string = "libpq"
+	formatEnv   string = "env"
+)
+
+var connectionFormats = []string{formatURI, formatJDBC, formatLibpq, formatEnv}
+
+// ConnectionOptions selects the user, database and endpoint of a connection
+type ConnectionOptions struct {
+	User     string
+	Database string
+	Pool     string
+	Replica  string
+	Public   bool
+}
+
+// Connection holds the details needed to connect to a managed database
+type Connection struct {
+	Engine   string
+	Host     string
+	Port     string
+	User     string
+	Password string
+	Database string
+}
+
+// connection resolves the connection details of the database.  The default
+// admin credentials are used unless a user or a connection pool is selected.
+func (o *options) connection(opts *ConnectionOptions) (*Connection, error) { //nolint:gocyclo
+	db, _, err := o.Base.Client.Database.Get(o.Base.Context, o.Base.Args[0])
+	if err != nil {
+		return nil, fmt.Errorf("error retrieving database : %v", err)
+	}
+
+	switch db.DatabaseEngine {
+	case engineMySQL, enginePG, engineValkey, engineKafka:
+	default:
+		return nil, fmt.Errorf("connection strings are not supported for the %s engine", db.DatabaseEngine)
+	}
+
+	c := &Connection{
+		Engine:   db.DatabaseEngine,
+		Host:     db.Host,
+		Port:     db.Port,
+		User:     db.User,
+		Password: db.Password,
+		Database: db.DBName,
+	}
+
+	if db.DatabaseEngine == engineKafka && db.SASLPort != "" {
+		c.Port = db.SASLPort
+	}
+
+	if opts.Replica != "" {
+		replica, err := findReplica(db, opts.Replica)
+		if err != nil {
+			return nil, err
+		}
+		db = replica
+		c.Host = replica.Host
+		c.Port = replica.Port
+	}
+
+	if opts.Public {
+		if db.PublicHost == "" {
+			return nil, errors.New("the database has no public host")
+		}
+		c.Host = db.PublicHost
+	}
+
+	user := opts.User
+	if opts.Pool != "" {
+		if c.Engine != enginePG {
+			return nil, errors.New("connection pools are only available for PostgreSQL databases")
+		}
+
+		pool, _, err := o.Base.Client.Database.GetConnectionPool(o.Base.Context, o.Base.Args[0], opts.Pool)
+		if err != nil {
+			return nil, fmt.Errorf("error retrieving connection pool %s : %v", opts.Pool, err)
+		}
+
+		// The pool is addressed by its name in place of the database name
+		c.Database = pool.Name
+		if user == "" {
+			user = pool.Username
+		}
+	}
+
+	if user != "" && user != c.User {
+		u, _, err := o.Base.Client.Database.GetUser(o.Base.Context, o.Base.Args[0], user)
+		if err != nil {
+			return nil, fmt.Errorf("error retrieving user %s : %v", user, err)
+		}
+		c.User = u.Username
+		c.Password = u.Password
+	}
+
+	if opts.Database != "" {
+		if opts.Pool != "" {
+			return nil, errors.New("a database cannot be selected along with a connection pool")
+		}
+		c.Database = opts.Database
+	}
+
+	return c, nil
+}
+
+// findReplica returns the read replica of the database with the ID or label
+func findReplica(db *govultr.Database, idOrLabel string) (*govultr.Database, error) {
+	var found []*govultr.Database
+	for i := range db.ReadReplicas {
+		if db.ReadReplicas[i].ID == idOrLabel {
+			return &db.ReadReplicas[i], nil
+		}
+
+		if db.ReadReplicas[i].Label == idOrLabel {
+			found = append(found, &db.ReadReplicas[i])
+		}
+	}
+
+	switch len(found) {
+	case 0:
+		return nil, fmt.Errorf("no read replica %s found for database %s", idOrLabel, db.ID)
+	case 1:
+		return found[0], nil
+	default:
+		return nil, fmt.Errorf("more than one read replica is labeled %s, use the replica ID", idOrLabel)
+	}
+}
+
+// String returns the connection in the format
+func (c *Connection) String(format string) (string, error) {
+	switch format {
+	case formatURI:
+		return c.uri(), nil
+	case formatJDBC:
+		return c.jdbc()
+	case formatLibpq:
+		if c.Engine != enginePG {
+			return "", errors.New("the libpq format is only available for PostgreSQL databases")
+		}
+		return c.libpq(), nil
+	case formatEnv:
+		var lines []string
+		for _, kv := range c.env() {
+			k, v, _ := strings.Cut(kv, "=")
+			lines = append(lines, fmt.Sprintf("export %s=%s", k, shellQuote(v)))
+		}
+		return strings.Join(lines, "\n"), nil
+	default:
+		return "", fmt.Errorf("invalid format %s, must be one of %s", format, strings.Join(connectionFormats, ", "))
+	}
+}
+
+func (c *Connection) hostPort() string {
+	return net.JoinHostPort(c.Host, c.Port)
+}
+
+// uri returns the engine specific connection URI.  Kafka has no URI scheme so
+// the bootstrap server is returned.
+func (c *Connection) uri() string {
+	u := &url.URL{
+		User: url.UserPassword(c.User, c.Password),
+		Host: c.hostPort(),
+	}
+
+	switch c.Engine {
+	case engineMySQL:
+		u.Scheme = "mysql"
+		u.Path = "/" + c.Database
+		u.RawQuery = "ssl-mode=REQUIRED"
+	case enginePG:
+		u.Scheme = "postgresql"
+		u.Path = "/" + c.Database
+		u.RawQuery = "sslmode=require"
+	case engineValkey:
+		u.Scheme = "rediss"
+		if c.Database != "" {
+			u.Path = "/" + c.Database
+		}
+	case engineKafka:
+		return c.hostPort()
+	}
+
+	return u.String()
+}
+
+func (c *Connection) jdbc() (string, error) {
+	q := url.Values{}
+	q.Set("user", c.User)
+	q.Set("password", c.Password)
+
+	switch c.Engine {
+	case engineMySQL:
+		q.Set("sslMode", "REQUIRED")
+		return fmt.Sprintf("jdbc:mysql://%s/%s?%s", c.hostPort(), url.PathEscape(c.Database), q.Encode()), nil
+	case enginePG:
+		q.Set("sslmode", "require")
+		return fmt.Sprintf("jdbc:postgresql://%s/%s?%s", c.hostPort(), url.PathEscape(c.Database), q.Encode()), nil
+	default:
+		return "", errors.New("the jdbc format is only available for MySQL and PostgreSQL databases")
+	}
+}
+
+func (c *Connection) libpq() string {
+	params := []string{
+		"host=" + libpqQuote(c.Host),
+		"port=" + libpqQuote(c.Port),
+		"user=" + libpqQuote(c.User),
+		"password=" + libpqQuote(c.Password),
+		"dbname=" + libpqQuote(c.Database),
+		"sslmode=require",
+	}
+	return strings.Join(params, " ")
+}
+
+// env returns the environment variables read by the engine's clients as
+// KEY=value pairs
+func (c *Connection) env() []string {
+	switch c.Engine {
+	case engineMySQL:
+		return []string{
+			"MYSQL_HOST=" + c.Host,
+			"MYSQL_TCP_PORT=" + c.Port,
+			"MYSQL_USER=" + c.User,
+			"MYSQL_PWD=" + c.Password,
+			"MYSQL_DATABASE=" + c.Database,
+		}
+	case enginePG:
+		return []string{
+			"PGHOST=" + c.Host,
+			"PGPORT=" + c.Port,
+			"PGUSER=" + c.User,
+			"PGPASSWORD=" + c.Password,
+			"PGDATABASE=" + c.Database,
+			"PGSSLMODE=require",
+		}
+	case engineValkey:
+		return []string{
+			"REDIS_HOST=" + c.Host,
+			"REDIS_PORT=" + c.Port,
+			"REDIS_USER=" + c.User,
+			"REDISCLI_AUTH=" + c.Password,
+		}
+	case engineKafka:
+		return []string{
+			"KAFKA_BOOTSTRAP_SERVERS=" + c.hostPort(),
+			"KAFKA_SASL_USERNAME=" + c.User,
+			"KAFKA_SASL_PASSWORD=" + c.Password,
+			"KAFKA_SECURITY_PROTOCOL=SASL_SSL",
+		}
+	}
+
+	return nil
+}
+
+// Command returns the local client command for the connection.  The password
+// is passed in the environment so it does not show up in the process list.
+func (c *Connection) Command(client string, extra []string) (*exec.Cmd, error) {
+	var args, env []string
+	switch c.Engine {
+	case enginePG:
+		if client == "" {
+			client = "psql"
+		}
+		env = c.env()
+	case engineMySQL:
+		if client == "" {
+			client = "mysql"
+		}
+		args = []string{"--host", c.Host, "--port", c.Port, "--user", c.User, "--ssl-mode=REQUIRED"}
+		if c.Database != "" {
+			args = append(args, "--database", c.Database)
+		}
+		env = []string{"MYSQL_PWD=" + c.Password}
+	case engineValkey:
+		if client == "" {
+			client = "redis-cli"
+		}
+		args = []string{"-h", c.Host, "-p", c.Port, "--user", c.User, "--tls"}
+		if c.Database != "" {
+			args = append(args, "-n", c.Database)
+		}
+		env = []string{"REDISCLI_AUTH=" + c.Password}
+	default:
+		return nil, fmt.Errorf("no database client is available for the %s engine", c.Engine)
+	}
+
+	path, err := exec.LookPath(client)
+	if err != nil {
+		return nil, fmt.Errorf("unable to find the %s client : %v", client, err)
+	}
+
+	cmd := exec.Command(path, append(args, extra...)...) //nolint:gosec
+	cmd.Env = append(os.Environ(), env...)
+	cmd.Stdin = os.Stdin
+	cmd.Stdout = os.Stdout
+	cmd.Stderr = os.Stderr
+
+	return cmd, nil
+}
+
+// shellQuote quotes the value for a POSIX shell
+func shellQuote(s string) string {
+	return "'" + strings.ReplaceAll(s, "'", `'\''`) + "'"
+}
+
+// libpqQuote quotes a libpq connection string value when needed
+func libpqQuote(s string) string {
+	if s != "" && !strings.ContainsAny(s, ` '\`) {
+		return s
+	}
+	r := strings.NewReplacer(`\`, `\\`, `'`, `\'`)
+	return "'" + r.Replace(s) + "'"
+}
@@ -0,0 +1,359 @@
+package database
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/vultr/govultr/v3"
+	"github.com/vultr/vultr-cli/v3/cmd/utils"
+	"gopkg.in/yaml.v3"
+)
+
+const (
+	syncActionCreate    string = "create"
+	syncActionUpdate    string = "update"
+	syncActionDelete    string = "delete"
+	syncActionUnchanged string = "unchanged"
+	syncActionUnmanaged string = "unmanaged"
+)
+
+// connectorFieldKeys are the config keys set from the connector's own fields
+var connectorFieldKeys = map[string]bool{
+	"name":            true,
+	"connector.class": true,
+	"topics":          true,
+}
+
+// ConnectorSet is the document of Kafka connectors used by connector apply
+type ConnectorSet struct {
+	Connectors []ConnectorSpec `yaml:"connectors"`
+}
+
+// ConnectorSpec is a Kafka connector of a ConnectorSet
+type ConnectorSpec struct {
+	Name   string                 `yaml:"name"`
+	Class  string                 `yaml:"class"`
+	Topics string                 `yaml:"topics,omitempty"`
+	Config map[string]interface{} `yaml:"config,omitempty"`
+}
+
+// ConnectorChange is the outcome of applying a single connector
+type ConnectorChange struct {
+	Action string `json:"action"`
+	Name   string `json:"name"`
+	Class  string `json:"class"`
+	Topics string `json:"topics"`
+}
+
+// readConnectorConfig reads a YAML or JSON connector config file
+func readConnectorConfig(path string) (map[string]interface{}, error) {
+	fd, err := os.ReadFile(filepath.Clean(path))
+	if err != nil {
+		return nil, fmt.Errorf("error reading connector config file : %v", err)
+	}
+
+	config := map[string]interface{}{}
+	if err := yaml.Unmarshal(fd, &config); err != nil {
+		return nil, fmt.Errorf("error parsing connector config file : %v", err)
+	}
+
+	return config, nil
+}
+
+// NewConnectorSetFromFile reads in a YAML or JSON connectors file
+func NewConnectorSetFromFile(path string) (*ConnectorSet, error) {
+	fd, err := os.ReadFile(filepath.Clean(path))
+	if err != nil {
+		return nil, fmt.Errorf("error reading connectors file : %v", err)
+	}
+
+	dec := yaml.NewDecoder(bytes.NewReader(fd))
+	dec.KnownFields(true)
+
+	cs := &ConnectorSet{}
+	if err := dec.Decode(cs); err != nil {
+		return nil, fmt.Errorf("error parsing connectors file : %v", err)
+	}
+
+	var errs []string
+	seen := map[string]bool{}
+	for i := range cs.Connectors {
+		if cs.Connectors[i].Name == "" || cs.Connectors[i].Class == "" {
+			errs = append(errs, fmt.Sprintf("connectors[%d] requires a name and a class", i))
+		}
+
+		if seen[cs.Connectors[i].Name] {
+			errs = append(errs, fmt.Sprintf("connectors[%d] name %s is used more than once", i, cs.Connectors[i].Name))
+		}
+		seen[cs.Connectors[i].Name] = true
+	}
+
+	if len(errs) > 0 {
+		return nil, fmt.Errorf("invalid connectors file :\n  %s", strings.Join(errs, "\n  "))
+	}
+
+	return cs, nil
+}
+
+// validateConnectorConfig checks the config against the configuration schema
+// of the connector class.  Lists are joined into the comma separated strings
+// Kafka Connect expects.  Keys which are not in the schema are returned as
+// warnings since connectors accept settings such as transforms which the
+// schema does not describe.
+func validateConnectorConfig(
+	config map[string]interface{},
+	schema []govultr.DatabaseConnectorConfigurationOption,
+) ([]string, error) {
+	var errs, warnings []string
+	options := map[string]*govultr.DatabaseConnectorConfigurationOption{}
+	for i := range schema {
+		opt := &schema[i]
+		options[opt.Name] = opt
+
+		if _, ok := config[opt.Name]; !ok && opt.Required && opt.DefaultValue == "" && !connectorFieldKeys[opt.Name] {
+			errs = append(errs, fmt.Sprintf("%s is required", opt.Name))
+		}
+	}
+
+	for _, k := range sortedKeys(config) {
+		opt, ok := options[k]
+		if !ok {
+			warnings = append(warnings, fmt.Sprintf("%s is not in the connector configuration schema", k))
+			continue
+		}
+
+		v, err := connectorValue(opt.Type, config[k])
+		if err != nil {
+			errs = append(errs, fmt.Sprintf("%s %v", k, err))
+			continue
+		}
+		config[k] = v
+	}
+
+	if len(errs) > 0 {
+		return warnings, fmt.Errorf("invalid connector config :\n  %s", strings.Join(errs, "\n  "))
+	}
+
+	return warnings, nil
+}
+
+// connectorValue checks the value against the Kafka Connect config type
+func connectorValue(typ string, value interface{}) (interface{}, error) {
+	s := fmt.Sprint(value)
+	switch strings.ToUpper(typ) {
+	case "INT", "SHORT", "LONG":
+		if _, err := strconv.ParseInt(s, 10, 64); err != nil {
+			return nil, fmt.Errorf("must be an integer, got %v", value)
+		}
+	case "DOUBLE":
+		if _, err := strconv.ParseFloat(s, 64); err != nil {
+			return nil, fmt.Errorf("must be a number, got %v", value)
+		}
+	case "BOOLEAN":
+		if _, err := strconv.ParseBool(s); err != nil {
+			return nil, fmt.Errorf("must be true or false, got %v", value)
+		}
+	case "LIST":
+		if items, ok := value.([]interface{}); ok {
+			var list []string
+			for i := range items {
+				list = append(list, fmt.Sprint(items[i]))
+			}
+			return strings.Join(list, ","), nil
+		}
+	default:
+		if _, ok := value.([]interface{}); ok {
+			return nil, errors.New("must be a single value, got a list")
+		}
+		if _, ok := value.(map[string]interface{}); ok {
+			return nil, errors.New("must be a single value, got a map")
+		}
+	}
+
+	return value, nil
+}
+
+// validateConnector validates the config of a connector against the schema
+// of its class, printing any warnings to stderr
+func (o *options) validateConnector(class string, config map[string]interface{}) error {
+	schema, _, err := o.Base.Client.Database.GetConnectorConfigurationSchema(o.Base.Context, o.Base.Args[0], class)
+	if err != nil {
+		return fmt.Errorf("error retrieving configuration schema for connector class %s : %v", class, err)
+	}
+
+	warnings, err := validateConnectorConfig(config, schema)
+	for i := range warnings {
+		fmt.Fprintf(os.Stderr, "warning: %s\n", warnings[i])
+	}
+
+	return err
+}
+
+// connectorConfigEqual returns whether the configured connector has the
+// desired config values.  Keys which are only in the configured connector,
+// such as defaults filled in by Kafka Connect, are ignored.
+func connectorConfigEqual(desired, configured map[string]interface{}) bool {
+	for k, v := range desired {
+		c, ok := configured[k]
+		if !ok || fmt.Sprint(c) != fmt.Sprint(v) {
+			return false
+		}
+	}
+	return true
+}
+
+// applyConnectors reconciles the Kafka connectors of the database with the
+// set.  Connectors which are not in the set are only deleted with
+// deleteUnmanaged.
+func (o *options) applyConnectors( //nolint:gocyclo
+	cs *ConnectorSet,
+	deleteUnmanaged, dryRun bool,
+) ([]ConnectorChange, error) {
+	// Validate every connector before changing any of them
+	for i := range cs.Connectors {
+		if cs.Connectors[i].Config == nil {
+			cs.Connectors[i].Config = map[string]interface{}{}
+		}
+
+		if err := o.validateConnector(cs.Connectors[i].Class, cs.Connectors[i].Config); err != nil {
+			return nil, fmt.Errorf("connector %s : %v", cs.Connectors[i].Name, err)
+		}
+	}
+
+	current, _, err := o.listConnectors()
+	if err != nil {
+		return nil, fmt.Errorf("error retrieving database connectors : %v", err)
+	}
+
+	existing := map[string]*govultr.DatabaseConnector{}
+	for i := range current {
+		existing[current[i].Name] = &current[i]
+	}
+
+	for i := range cs.Connectors {
+		want := &cs.Connectors[i]
+		if cur, ok := existing[want.Name]; ok && cur.Class != want.Class {
+			return nil, fmt.Errorf(
+				"connector %s has the class %s which cannot be changed to %s, delete the connector first",
+				want.Name,
+				cur.Class,
+				want.Class,
+			)
+		}
+	}
+
+	var changes []ConnectorChange
+	managed := map[string]bool{}
+	for i := range cs.Connectors {
+		want := &cs.Connectors[i]
+		managed[want.Name] = true
+		change := ConnectorChange{Name: want.Name, Class: want.Class, Topics: want.Topics}
+
+		cur, ok := existing[want.Name]
+		if !ok {
+			change.Action = syncActionCreate
+			if !dryRun {
+				if _, _, err := o.Base.Client.Database.CreateConnector(
+					o.Base.Context,
+					o.Base.Args[0],
+					&govultr.DatabaseConnectorCreateReq{
+						Name:   want.Name,
+						Class:  want.Class,
+						Topics: want.Topics,
+						Config: want.Config,
+					},
+				); err != nil {
+					return changes, fmt.Errorf("error creating connector %s : %v", want.Name, err)
+				}
+			}
+			changes = append(changes, change)
+			continue
+		}
+
+		change.Action = syncActionUnchanged
+		if cur.Topics != want.Topics || !connectorConfigEqual(want.Config, cur.Config) {
+			change.Action = syncActionUpdate
+			if !dryRun {
+				if _, _, err := o.Base.Client.Database.UpdateConnector(
+					o.Base.Context,
+					o.Base.Args[0],
+					want.Name,
+					&govultr.DatabaseConnectorUpdateReq{
+						Topics: want.Topics,
+						Config: want.Config,
+					},
+				); err != nil {
+					return changes, fmt.Errorf("error updating connector %s : %v", want.Name, err)
+				}
+			}
+		}
+		changes = append(changes, change)
+	}
+
+	for i := range current {
+		if managed[current[i].Name] {
+			continue
+		}
+
+		change := ConnectorChange{
+			Action: syncActionUnmanaged,
+			Name:   current[i].Name,
+			Class:  current[i].Class,
+			Topics: current[i].Topics,
+		}
+
+		if deleteUnmanaged {
+			change.Action = syncActionDelete
+			if !dryRun {
+				if err := o.Base.Client.Database.DeleteConnector(
+					o.Base.Context,
+					o.Base.Args[0],
+					current[i].Name,
+				); err != nil {
+					return changes, fmt.Errorf("error deleting connector %s : %v", current[i].Name, err)
+				}
+			}
+		}
+		changes = append(changes, change)
+	}
+
+	return changes, nil
+}
+
+// confirmSyncDeletes lists the resources which will be deleted on stderr and
+// prompts for confirmation when there are any
+func confirmSyncDeletes(deletes []string) error {
+	if len(deletes) == 0 {
+		return nil
+	}
+
+	fmt.Fprintf(os.Stderr, "The following resources are not in the file and will be deleted:\n")
+	for i := range deletes {
+		fmt.Fprintf(os.Stderr, "  %s\n", deletes[i])
+	}
+
+	return utils.Prompt(os.Stdin, os.Stderr, "Apply these changes?")
+}
+
+// confirmConnectorDeletes plans the connectors file and prompts for
+// confirmation when connectors would be deleted
+func (o *options) confirmConnectorDeletes(cs *ConnectorSet) error {
+	plan, err := o.applyConnectors(cs, true, true)
+	if err != nil {
+		return fmt.Errorf("error planning database connectors : %v", err)
+	}
+
+	var deletes []string
+	for i := range plan {
+		if plan[i].Action == syncActionDelete {
+			deletes = append(deletes, "connector "+plan[i].Name)
+		}
+	}
+
+	return confirmSyncDeletes(deletes)
+}
@@ -6,10 +6,13 @@ import (
 	"errors"
 	"fmt"
 	"os"
+	"os/exec"
 	"strconv"
+	"strings"
 
 	"github.com/spf13/cobra"
 	"github.com/vultr/govultr/v3"
+	"github.com/vultr/vultr-cli/v3/cmd/cost"
 	"github.com/vultr/vultr-cli/v3/cmd/printer"
 	"github.com/vultr/vultr-cli/v3/cmd/utils"
 	"github.com/vultr/vultr-cli/v3/pkg/cli"
@@ -48,6 +51,135 @@ var (
 	# Full example with custom MySQL settings
 	vultr-cli database update --mysql-slow-query-log="true" --mysql-long-query-time="2"
 	`
+	connectionStringLong = `Print a connection string for a Managed Database built from its connection details.
+
+The admin user and default database are used unless --user or --db are given.  PostgreSQL databases can be
+reached through a connection pool with --pool and any database through one of its read replicas with --replica.
+The formats are uri, jdbc (MySQL and PostgreSQL), libpq (PostgreSQL) and env, which prints shell exports of the
+variables read by the engine's clients.  Kafka has no URI scheme so the uri format is the bootstrap server.`
+	connectionStringExample = `
+	# Full example
+	vultr-cli database connection-string 9d6a7e6c-3d1f-4ae4-8b5e-5a2f0c4fb8c1
+
+	# Connect as another user to another database in the JDBC format
+	vultr-cli database connection-string 9d6a7e6c-3d1f-4ae4-8b5e-5a2f0c4fb8c1 --user app --db appdb --format jdbc
+
+	# Load the connection details of a connection pool into the shell
+	eval "$(vultr-cli database connection-string 9d6a7e6c-3d1f-4ae4-8b5e-5a2f0c4fb8c1 --pool app-pool --format env)"
+	`
+	connectLong = `Start the local database client connected to a Managed Database.
+
+psql is used for PostgreSQL, mysql for MySQL and redis-cli for Valkey, or the client given with --client.  The
+password is passed to the client in its environment rather than on the command line.  Arguments after -- are
+passed on to the client.`
+	connectorApplyLong = `Reconcile the Kafka connectors of a managed database with a YAML or JSON connectors file.
+
+Each connector config is validated against the configuration schema of its class before any change is made.
+Connectors in the file are created or updated to match, connectors which are not in the file are left alone
+unless --delete is given.  The connectors to delete are listed on stderr with a confirmation prompt first, unless
+skipped by --yes or VULTR_CLI_ASSUME_YES.  The connectors file has the following format:
+
+	connectors:
+	  - name: s3-sink
+	    class: io.aiven.kafka.connect.s3.AivenKafkaConnectS3SinkConnector
+	    topics: events
+	    config:
+	      aws_s3_bucket_name: example-bucket
+	      aws_s3_region: us-east-1
+`
+	connectorApplyExample = `
+	# Full example
+	vultr-cli database connector apply 9d6a7e6c-3d1f-4ae4-8b5e-5a2f0c4fb8c1 --file connectors.yaml
+
+	# Show the changes, including deletions, without applying them
+	vultr-cli database connector apply 9d6a7e6c-3d1f-4ae4-8b5e-5a2f0c4fb8c1 -f connectors.yaml --delete --dry-run
+
+	# Delete the connectors which are not in the file without a confirmation prompt
+	vultr-cli database connector apply 9d6a7e6c-3d1f-4ae4-8b5e-5a2f0c4fb8c1 -f connectors.yaml --delete --yes
+	`
+	kafkaApplyLong = `Converge the topics, users and client quotas of a Kafka managed database on a YAML or JSON spec.
+
+Resources in the spec are created or updated to match and those which are not in it are deleted.  Only the
+sections present in the spec are managed, so leaving out quotas leaves the quotas alone while "quotas: []"
+deletes them all.  The default admin user is never deleted and topic partitions can only be increased.
+The resources to delete are listed on stderr with a confirmation prompt first, unless skipped by --yes or
+VULTR_CLI_ASSUME_YES.  The spec has the following format:
+
+	topics:
+	  - name: events
+	    partitions: 3
+	    replication: 2
+	    retention_hours: 168
+	    retention_bytes: -1
+	users:
+	  - username: app
+	    permission: readwrite
+	quotas:
+	  - client_id: app-client
+	    user: app
+	    consumer_byte_rate: 1048576
+	    producer_byte_rate: 1048576
+	    request_percentage: 50
+`
+	kafkaApplyExample = `
+	# Show the plan without applying it
+	vultr-cli database kafka apply 9d6a7e6c-3d1f-4ae4-8b5e-5a2f0c4fb8c1 -f kafka.yaml --dry-run
+
+	# Full example
+	vultr-cli database kafka apply 9d6a7e6c-3d1f-4ae4-8b5e-5a2f0c4fb8c1 --file kafka.yaml
+
+	# Apply the spec, including deletions, without a confirmation prompt
+	vultr-cli database kafka apply 9d6a7e6c-3d1f-4ae4-8b5e-5a2f0c4fb8c1 -f kafka.yaml --yes
+	`
+	migrationStartLong = `Start migrating data from a source database into a managed database.
+
+With --wait the migration status is polled and its progress displayed on stderr until the source data has
+been copied, and any migration error is returned.  A replication migration then keeps syncing changes from the
+source until it is detached.  --detach detaches it as soon as syncing starts: the API does not report the
+replication lag, so it cannot wait for replication to catch up.  Stop writes to the source before using --detach
+or changes which are not synced yet are lost.  The source password can be read from a file with --password-file
+or from stdin with --password-stdin to keep it out of the shell history.`
+	migrationStartExample = `
+	# Wait for the migration, reading the source password from stdin
+	cat password.txt | vultr-cli database migration start 9d6a7e6c-3d1f-4ae4-8b5e-5a2f0c4fb8c1 \
+		--host 192.0.2.10 --port 5432 --username postgres --password-stdin --wait
+
+	# Detach once syncing starts without prompting, after stopping writes to the source
+	vultr-cli database migration start 9d6a7e6c-3d1f-4ae4-8b5e-5a2f0c4fb8c1 --host 192.0.2.10 --port 5432 \
+		--username postgres --password-file password.txt --wait --detach --yes
+	`
+	advancedOptionDiffLong = `Compare the configured advanced options of a managed database with the desired options given
+with --file and --set.  The desired options are validated against the available options first.
+
+There is no column of default values since the available options reported by the API do not include them.
+Options which are not configured are shown as (not configured) and keep the server side default.`
+	statusLong = `Display a health report of a managed database aggregating its status, disk, memory and CPU usage,
+pending maintenance updates, recent service alerts, read replicas and, for PostgreSQL, connection pool
+utilization.  The data is retrieved concurrently.
+
+Each check is reported as OK, WARNING, CRITICAL or UNKNOWN and the command exits with the code of the worst
+check, following the convention of monitoring plugins: 0 for OK, 1 for WARNING, 2 for CRITICAL and 3 for
+UNKNOWN.  A critical check outranks an unknown one, so 3 is only used when no check is critical or when the
+database cannot be retrieved.  Utilization at or above the thresholds is a warning or critical, a database or
+replica which is not running is critical, and pending maintenance updates or alerts are warnings.  The API does
+not report replication lag, so read replicas are checked on their status and usage.`
+	statusExample = `
+	# Full example
+	vultr-cli database status 9d6a7e6c-3d1f-4ae4-8b5e-5a2f0c4fb8c1
+
+	# Custom thresholds for a monitoring check
+	vultr-cli database status 9d6a7e6c-3d1f-4ae4-8b5e-5a2f0c4fb8c1 --warning 70 --critical 85 -o json
+	`
+	connectExample = `
+	# Full example
+	vultr-cli database connect 9d6a7e6c-3d1f-4ae4-8b5e-5a2f0c4fb8c1
+
+	# Run a single query through a read replica
+	vultr-cli database connect 9d6a7e6c-3d1f-4ae4-8b5e-5a2f0c4fb8c1 --replica example-replica -- -c "select 1"
+
+	# Use a specific client binary
+	vultr-cli database connect 9d6a7e6c-3d1f-4ae4-8b5e-5a2f0c4fb8c1 --client mariadb
+	`
 )
 
 // NewCmdDatabase provides the CLI command for database functions
@@ -234,6 +366,11 @@ func NewCmdDatabase(base *cli.Base) *cobra.Command { //nolint:funlen,gocyclo
 				return fmt.Errorf("error parsing flag 'enable-kafka-connect' for database create : %v", errEe)
 			}
 
+			estimate, errEs := cmd.Flags().GetBool("estimate")
+			if errEs != nil {
+				return fmt.Errorf("error parsing flag 'estimate' for database create : %v", errEs)
+			}
+
 			o.CreateReq = &govultr.DatabaseCreateReq{
 				DatabaseEngine:         engine,
 				DatabaseEngineVersion:  engineVersion,
@@ -257,6 +394,10 @@ func NewCmdDatabase(base *cli.Base) *cobra.Command { //nolint:funlen,gocyclo
 				EnableKafkaConnect:     &enableKafkaConnect,
 			}
 
+			if estimate {
+				return o.estimate()
+			}
+
 			db, err := o.create()
 			if err != nil {
 				return fmt.Errorf("error creating database : %v", err)
@@ -346,6 +487,7 @@ func NewCmdDatabase(base *cli.Base) *cobra.Command { //nolint:funlen,gocyclo
 		false,
 		"enable Kafka Connect for the new apache kafka managed database",
 	)
+	cost.AddFlag(create)
 
 	// Update
 	update := &cobra.Command{
@@ -597,6 +739,21 @@ func NewCmdDatabase(base *cli.Base) *cobra.Command { //nolint:funlen,gocyclo
 			return nil
 		},
 		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := utils.ConfirmDelete(cmd, func() (*utils.Resource, error) {
+				db, err := o.get()
+				if err != nil {
+					return nil, err
+				}
+
+				r := &utils.Resource{Kind: "database", ID: db.ID, Label: db.Label, Region: db.Region}
+				if db.Tag != "" {
+					r.Tags = []string{db.Tag}
+				}
+				return r, nil
+			}); err != nil {
+				return err
+			}
+
 			if err := o.del(); err != nil {
 				return fmt.Errorf("error deleting database : %v", err)
 			}
@@ -607,6 +764,8 @@ func NewCmdDatabase(base *cli.Base) *cobra.Command { //nolint:funlen,gocyclo
 		},
 	}
 
+	utils.AddConfirmFlags(del)
+
 	// Plan
 	plan := &cobra.Command{
 		Use:   "plan",
@@ -1549,6 +1708,16 @@ func NewCmdDatabase(base *cli.Base) *cobra.Command { //nolint:funlen,gocyclo
 				return fmt.Errorf("error parsing flag 'config' for database connector create : %v", err)
 			}
 
+			configFile, err := cmd.Flags().GetString("config-file")
+			if err != nil {
+				return fmt.Errorf("error parsing flag 'config-file' for database connector create : %v", err)
+			}
+
+			skipValidation, err := cmd.Flags().GetBool("skip-validation")
+			if err != nil {
+				return fmt.Errorf("error parsing flag 'skip-validation' for database connector create : %v", err)
+			}
+
 			var configMap map[string]interface{}
 			if config != "" {
 				if err := json.Unmarshal([]byte(config), &configMap); err != nil {
@@ -1556,6 +1725,23 @@ func NewCmdDatabase(base *cli.Base) *cobra.Command { //nolint:funlen,gocyclo
 				}
 			}
 
+			if configFile != "" {
+				configMap, err = readConnectorConfig(configFile)
+				if err != nil {
+					return err
+				}
+			}
+
+			if !skipValidation {
+				if configMap == nil {
+					configMap = map[string]interface{}{}
+				}
+
+				if err := o.validateConnector(class, configMap); err != nil {
+					return err
+				}
+			}
+
 			o.ConnectorCreateReq = &govultr.DatabaseConnectorCreateReq{
 				Name:   name,
 				Class:  class,
@@ -1579,6 +1765,17 @@ func NewCmdDatabase(base *cli.Base) *cobra.Command { //nolint:funlen,gocyclo
 	connectorCreate.Flags().StringP("class", "c", "", "class for the new managed database connector")
 	connectorCreate.Flags().StringP("topics", "t", "", "topics for the new managed database connector")
 	connectorCreate.Flags().StringP("config", "", "", "configuration json for the new managed database connector")
+	connectorCreate.Flags().String(
+		"config-file",
+		"",
+		"path to a JSON or YAML configuration file for the new managed database connector",
+	)
+	connectorCreate.Flags().Bool(
+		"skip-validation",
+		false,
+		"(optional) skip validating the configuration against the connector configuration schema",
+	)
+	connectorCreate.MarkFlagsMutuallyExclusive("config", "config-file")
 
 	// Connector Update
 	connectorUpdate := &cobra.Command{
@@ -1601,6 +1798,16 @@ func NewCmdDatabase(base *cli.Base) *cobra.Command { //nolint:funlen,gocyclo
 				return fmt.Errorf("error parsing flag 'config' for database connector update : %v", err)
 			}
 
+			configFile, err := cmd.Flags().GetString("config-file")
+			if err != nil {
+				return fmt.Errorf("error parsing flag 'config-file' for database connector update : %v", err)
+			}
+
+			skipValidation, err := cmd.Flags().GetBool("skip-validation")
+			if err != nil {
+				return fmt.Errorf("error parsing flag 'skip-validation' for database connector update : %v", err)
+			}
+
 			var configMap map[string]interface{}
 			if config != "" {
 				if err := json.Unmarshal([]byte(config), &configMap); err != nil {
@@ -1608,13 +1815,31 @@ func NewCmdDatabase(base *cli.Base) *cobra.Command { //nolint:funlen,gocyclo
 				}
 			}
 
+			if configFile != "" {
+				configMap, err = readConnectorConfig(configFile)
+				if err != nil {
+					return err
+				}
+			}
+
+			if configMap != nil && !skipValidation {
+				co, err := o.getConnector()
+				if err != nil {
+					return fmt.Errorf("error retrieving database connector : %v", err)
+				}
+
+				if err := o.validateConnector(co.Class, configMap); err != nil {
+					return err
+				}
+			}
+
 			o.ConnectorUpdateReq = &govultr.DatabaseConnectorUpdateReq{}
 
 			if cmd.Flags().Changed("topics") {
 				o.ConnectorUpdateReq.Topics = topics
 			}
 
-			if cmd.Flags().Changed("config") {
+			if cmd.Flags().Changed("config") || cmd.Flags().Changed("config-file") {
 				o.ConnectorUpdateReq.Config = configMap
 			}
 
@@ -1632,6 +1857,86 @@ func NewCmdDatabase(base *cli.Base) *cobra.Command { //nolint:funlen,gocyclo
 
 	connectorUpdate.Flags().StringP("topics", "t", "", "topics for the managed database connector")
 	connectorUpdate.Flags().StringP("config", "c", "", "configuration json for the managed database connector")
+	connectorUpdate.Flags().String(
+		"config-file",
+		"",
+		"path to a JSON or YAML configuration file for the managed database connector",
+	)
+	connectorUpdate.Flags().Bool(
+		"skip-validation",
+		false,
+		"(optional) skip validating the configuration against the connector configuration schema",
+	)
+	connectorUpdate.MarkFlagsMutuallyExclusive("config", "config-file")
+
+	// Connector Apply
+	connectorApply := &cobra.Command{
+		Use:     "apply <Database ID>",
+		Short:   "Apply a connectors file to a Kafka database",
+		Long:    connectorApplyLong,
+		Example: connectorApplyExample,
+		Args: func(cmd *cobra.Command, args []string) error {
+			if len(args) != 1 {
+				return errors.New("please provide a database ID")
+			}
+			return nil
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			file, err := cmd.Flags().GetString("file")
+			if err != nil {
+				return fmt.Errorf("error parsing flag 'file' for database connector apply : %v", err)
+			}
+
+			deleteUnmanaged, err := cmd.Flags().GetBool("delete")
+			if err != nil {
+				return fmt.Errorf("error parsing flag 'delete' for database connector apply : %v", err)
+			}
+
+			dryRun, err := cmd.Flags().GetBool("dry-run")
+			if err != nil {
+				return fmt.Errorf("error parsing flag 'dry-run' for database connector apply : %v", err)
+			}
+
+			yes, err := cmd.Flags().GetBool("yes")
+			if err != nil {
+				return fmt.Errorf("error parsing flag 'yes' for database connector apply : %v", err)
+			}
+
+			cs, err := NewConnectorSetFromFile(file)
+			if err != nil {
+				return err
+			}
+
+			if deleteUnmanaged && !dryRun && !yes && !utils.AssumeYes() {
+				if errCo := o.confirmConnectorDeletes(cs); errCo != nil {
+					return errCo
+				}
+			}
+
+			changes, err := o.applyConnectors(cs, deleteUnmanaged, dryRun)
+			if err != nil {
+				if len(changes) > 0 {
+					fmt.Fprintln(os.Stderr, "changes applied before the error :")
+					o.Base.Printer.Print(&ConnectorsApplyPrinter{Changes: changes})
+				}
+				return fmt.Errorf("error applying database connectors : %v", err)
+			}
+
+			data := &ConnectorsApplyPrinter{Changes: changes, DryRun: dryRun}
+			o.Base.Printer.Display(data, nil)
+
+			return nil
+		},
+	}
+
+	connectorApply.Flags().StringP("file", "f", "", "path to the YAML or JSON connectors file")
+	if err := connectorApply.MarkFlagRequired("file"); err != nil {
+		fmt.Printf("error marking database connector apply 'file' flag required: %v", err)
+		os.Exit(1)
+	}
+	connectorApply.Flags().Bool("delete", false, "(optional) Delete the connectors which are not in the file.")
+	connectorApply.Flags().Bool("dry-run", false, "(optional) Display the changes without applying them.")
+	connectorApply.Flags().BoolP("yes", "y", false, "(optional) Skip the confirmation prompt")
 
 	// Connector Delete
 	connectorDelete := &cobra.Command{
@@ -1772,6 +2077,80 @@ func NewCmdDatabase(base *cli.Base) *cobra.Command { //nolint:funlen,gocyclo
 		connectorPause,
 		connectorResume,
 		connectorRestartTask,
+		connectorApply,
+	)
+
+	// Kafka
+	kafka := &cobra.Command{
+		Use:   "kafka",
+		Short: "Commands to manage the resources of Kafka databases declaratively",
+	}
+
+	// Kafka Apply
+	kafkaApply := &cobra.Command{
+		Use:     "apply <Database ID>",
+		Short:   "Apply a Kafka spec file of topics, users and quotas",
+		Long:    kafkaApplyLong,
+		Example: kafkaApplyExample,
+		Args: func(cmd *cobra.Command, args []string) error {
+			if len(args) != 1 {
+				return errors.New("please provide a database ID")
+			}
+			return nil
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			file, err := cmd.Flags().GetString("file")
+			if err != nil {
+				return fmt.Errorf("error parsing flag 'file' for database kafka apply : %v", err)
+			}
+
+			dryRun, err := cmd.Flags().GetBool("dry-run")
+			if err != nil {
+				return fmt.Errorf("error parsing flag 'dry-run' for database kafka apply : %v", err)
+			}
+
+			yes, err := cmd.Flags().GetBool("yes")
+			if err != nil {
+				return fmt.Errorf("error parsing flag 'yes' for database kafka apply : %v", err)
+			}
+
+			spec, err := NewKafkaSpecFromFile(file)
+			if err != nil {
+				return err
+			}
+
+			if !dryRun && !yes && !utils.AssumeYes() {
+				if errCo := o.confirmKafkaDeletes(spec); errCo != nil {
+					return errCo
+				}
+			}
+
+			changes, err := o.applyKafka(spec, dryRun)
+			if err != nil {
+				if len(changes) > 0 {
+					fmt.Fprintln(os.Stderr, "changes applied before the error :")
+					o.Base.Printer.Print(&KafkaApplyPrinter{Changes: changes})
+				}
+				return fmt.Errorf("error applying kafka spec : %v", err)
+			}
+
+			data := &KafkaApplyPrinter{Changes: changes, DryRun: dryRun}
+			o.Base.Printer.Display(data, nil)
+
+			return nil
+		},
+	}
+
+	kafkaApply.Flags().StringP("file", "f", "", "path to the YAML or JSON kafka spec file")
+	if err := kafkaApply.MarkFlagRequired("file"); err != nil {
+		fmt.Printf("error marking database kafka apply 'file' flag required: %v", err)
+		os.Exit(1)
+	}
+	kafkaApply.Flags().Bool("dry-run", false, "(optional) Display the plan without applying it.")
+	kafkaApply.Flags().BoolP("yes", "y", false, "(optional) Skip the confirmation prompt")
+
+	kafka.AddCommand(
+		kafkaApply,
 	)
 
 	// Usage
@@ -1947,8 +2326,10 @@ func NewCmdDatabase(base *cli.Base) *cobra.Command { //nolint:funlen,gocyclo
 
 	// Migration Start
 	migrationStart := &cobra.Command{
-		Use:   "start <Database ID>",
-		Short: "Start a migration for a database",
+		Use:     "start <Database ID>",
+		Short:   "Start a migration for a database",
+		Long:    migrationStartLong,
+		Example: migrationStartExample,
 		Args: func(cmd *cobra.Command, args []string) error {
 			if len(args) != 1 {
 				return errors.New("please provide a database ID")
@@ -1976,6 +2357,55 @@ func NewCmdDatabase(base *cli.Base) *cobra.Command { //nolint:funlen,gocyclo
 				return fmt.Errorf("error parsing flag 'encryption' for migration start : %v", errPa)
 			}
 
+			passwordFile, errPf := cmd.Flags().GetString("password-file")
+			if errPf != nil {
+				return fmt.Errorf("error parsing flag 'password-file' for migration start : %v", errPf)
+			}
+
+			passwordStdin, errPs := cmd.Flags().GetBool("password-stdin")
+			if errPs != nil {
+				return fmt.Errorf("error parsing flag 'password-stdin' for migration start : %v", errPs)
+			}
+
+			wait, errWa := cmd.Flags().GetBool("wait")
+			if errWa != nil {
+				return fmt.Errorf("error parsing flag 'wait' for migration start : %v", errWa)
+			}
+
+			timeout, errTi := cmd.Flags().GetDuration("timeout")
+			if errTi != nil {
+				return fmt.Errorf("error parsing flag 'timeout' for migration start : %v", errTi)
+			}
+
+			detach, errDe := cmd.Flags().GetBool("detach")
+			if errDe != nil {
+				return fmt.Errorf("error parsing flag 'detach' for migration start : %v", errDe)
+			}
+
+			yes, errYe := cmd.Flags().GetBool("yes")
+			if errYe != nil {
+				return fmt.Errorf("error parsing flag 'yes' for migration start : %v", errYe)
+			}
+
+			if detach && !wait {
+				return errors.New("the 'detach' flag for migration start requires --wait")
+			}
+
+			if detach && passwordStdin && !yes && !utils.AssumeYes() {
+				return errors.New("the 'detach' flag for migration start requires --yes when the password is read from stdin")
+			}
+
+			if passwordStdin {
+				passwordFile = "-"
+			}
+
+			if passwordFile != "" {
+				var err error
+				if password, err = readMigrationPassword(passwordFile, os.Stdin); err != nil {
+					return err
+				}
+			}
+
 			database, errDa := cmd.Flags().GetString("database")
 			if errDa != nil {
 				return fmt.Errorf("error parsing flag 'encryption' for migration start : %v", errDa)
@@ -2006,6 +2436,50 @@ func NewCmdDatabase(base *cli.Base) *cobra.Command { //nolint:funlen,gocyclo
 				return fmt.Errorf("error retrieving database migration status : %v", err)
 			}
 
+			if wait {
+				mig, err = o.waitMigration(timeout)
+				if mig != nil {
+					data := &MigrationPrinter{Migration: mig}
+					o.Base.Printer.Print(data)
+				}
+
+				if err != nil {
+					return err
+				}
+
+				if mig.Status != migrationStatusSyncing {
+					return nil
+				}
+
+				if !detach {
+					fmt.Fprintf(
+						os.Stderr,
+						"migration is syncing, run 'vultr-cli database migration detach %s' to finish the migration "+
+							"once writes to the source have stopped\n",
+						args[0],
+					)
+					return nil
+				}
+
+				if !yes && !utils.AssumeYes() {
+					question := fmt.Sprintf(
+						"The replication lag is not reported. Detach the migration from database %s now?",
+						args[0],
+					)
+					if err := utils.Prompt(os.Stdin, os.Stderr, question); err != nil {
+						return err
+					}
+				}
+
+				if err := o.detachMigration(); err != nil {
+					return fmt.Errorf("error detaching migration from database : %v", err)
+				}
+
+				fmt.Fprintln(os.Stderr, "Migration detached")
+
+				return nil
+			}
+
 			data := &MigrationPrinter{Migration: mig}
 			o.Base.Printer.Display(data, nil)
 
@@ -2021,6 +2495,17 @@ func NewCmdDatabase(base *cli.Base) *cobra.Command { //nolint:funlen,gocyclo
 		"source username for the managed database migration (uses `default` for caching databases if omitted)",
 	)
 	migrationStart.Flags().String("password", "", "source password for the managed database migration")
+	migrationStart.Flags().String(
+		"password-file",
+		"",
+		"(optional) read the source password from the first line of a file instead of --password",
+	)
+	migrationStart.Flags().Bool(
+		"password-stdin",
+		false,
+		"(optional) read the source password from stdin instead of --password",
+	)
+	migrationStart.MarkFlagsMutuallyExclusive("password", "password-file", "password-stdin")
 	migrationStart.Flags().String(
 		"database",
 		"",
@@ -2032,6 +2517,18 @@ func NewCmdDatabase(base *cli.Base) *cobra.Command { //nolint:funlen,gocyclo
 		"comma-separated list of ignored databases for the managed database migration (MySQL/PostgreSQL only)",
 	)
 	migrationStart.Flags().Bool("ssl", true, "source ssl requirement for the managed database migration")
+	migrationStart.Flags().Bool(
+		"wait",
+		false,
+		"(optional) wait for the migration to copy the source data, displaying its progress",
+	)
+	migrationStart.Flags().Duration("timeout", migrationDefaultTimeout, "(optional) how long to wait for the migration")
+	migrationStart.Flags().Bool(
+		"detach",
+		false,
+		"(optional) detach the migration as soon as syncing starts, without waiting for replication lag. Requires --wait",
+	)
+	migrationStart.Flags().BoolP("yes", "y", false, "(optional) detach without a confirmation prompt")
 
 	// Migration Detach
 	migrationDetach := &cobra.Command{
@@ -2197,16 +2694,34 @@ func NewCmdDatabase(base *cli.Base) *cobra.Command { //nolint:funlen,gocyclo
 				return fmt.Errorf("error parsing flag 'date' for backup restore : %v", errDa)
 			}
 
-			time, errTi := cmd.Flags().GetString("time")
+			clock, errTi := cmd.Flags().GetString("time")
 			if errTi != nil {
 				return fmt.Errorf("error parsing flag 'time' for backup restore : %v", errTi)
 			}
 
+			pit, errPi := pointInTimeOptions(cmd, "backup restore")
+			if errPi != nil {
+				return errPi
+			}
+
+			if pit.At != "" || pit.Ago != 0 {
+				if rtype != "" && rtype != restoreTypePITR {
+					return fmt.Errorf("--at and --ago require the %s restoration type", restoreTypePITR)
+				}
+				rtype = restoreTypePITR
+
+				var err error
+				date, clock, err = o.pointInTime(pit.At, pit.Ago)
+				if err != nil {
+					return err
+				}
+			}
+
 			o.BackupReq = &govultr.DatabaseBackupRestoreReq{
 				Label: label,
 				Type:  rtype,
 				Date:  date,
-				Time:  time,
+				Time:  clock,
 			}
 
 			bk, err := o.restoreBackup()
@@ -2214,6 +2729,13 @@ func NewCmdDatabase(base *cli.Base) *cobra.Command { //nolint:funlen,gocyclo
 				return fmt.Errorf("error restoring database from backup : %v", err)
 			}
 
+			if pit.Wait {
+				bk, err = o.waitRunning(bk.ID, pit.Timeout)
+				if err != nil {
+					return err
+				}
+			}
+
 			data := &DBPrinter{DB: bk}
 			o.Base.Printer.Display(data, nil)
 
@@ -2234,6 +2756,7 @@ func NewCmdDatabase(base *cli.Base) *cobra.Command { //nolint:funlen,gocyclo
 	)
 	backupRestore.Flags().String("date", "", "backup date to use for point-in-time recovery")
 	backupRestore.Flags().String("time", "", "backup time to use for point-in-time recovery")
+	addPointInTimeFlags(backupRestore)
 
 	// Backup Fork
 	backupFork := &cobra.Command{
@@ -2271,18 +2794,36 @@ func NewCmdDatabase(base *cli.Base) *cobra.Command { //nolint:funlen,gocyclo
 				return fmt.Errorf("error parsing flag 'date' for backup fork: %v", errDa)
 			}
 
-			time, errTi := cmd.Flags().GetString("time")
+			clock, errTi := cmd.Flags().GetString("time")
 			if errTi != nil {
 				return fmt.Errorf("error parsing flag 'time' for backup fork: %v", errTi)
 			}
 
+			pit, errPi := pointInTimeOptions(cmd, "backup fork")
+			if errPi != nil {
+				return errPi
+			}
+
+			if pit.At != "" || pit.Ago != 0 {
+				if rtype != "" && rtype != restoreTypePITR {
+					return fmt.Errorf("--at and --ago require the %s restoration type", restoreTypePITR)
+				}
+				rtype = restoreTypePITR
+
+				var err error
+				date, clock, err = o.pointInTime(pit.At, pit.Ago)
+				if err != nil {
+					return err
+				}
+			}
+
 			o.ForkReq = &govultr.DatabaseForkReq{
 				Label:  label,
 				Region: region,
 				Plan:   plan,
 				Type:   rtype,
 				Date:   date,
-				Time:   time,
+				Time:   clock,
 			}
 
 			db, err := o.fork()
@@ -2290,6 +2831,13 @@ func NewCmdDatabase(base *cli.Base) *cobra.Command { //nolint:funlen,gocyclo
 				return fmt.Errorf("error forking database from backup : %v", err)
 			}
 
+			if pit.Wait {
+				db, err = o.waitRunning(db.ID, pit.Timeout)
+				if err != nil {
+					return err
+				}
+			}
+
 			data := &DBPrinter{DB: db}
 			o.Base.Printer.Display(data, nil)
 
@@ -2322,6 +2870,7 @@ func NewCmdDatabase(base *cli.Base) *cobra.Command { //nolint:funlen,gocyclo
 	)
 	backupFork.Flags().String("date", "", "backup date to use for point-in-time recovery")
 	backupFork.Flags().String("time", "", "backup time to use for point-in-time recovery")
+	addPointInTimeFlags(backupFork)
 
 	backup.AddCommand(
 		backupGet,
@@ -3290,7 +3839,24 @@ func NewCmdDatabase(base *cli.Base) *cobra.Command { //nolint:funlen,gocyclo
 				)
 			}
 
+			desired, errDe := desiredAdvancedOptions(cmd, "advanced options update")
+			if errDe != nil {
+				return errDe
+			}
+
 			o.AdvancedOptionsReq = &govultr.DatabaseAdvancedOptions{}
+			if desired != nil {
+				_, avail, errLi := o.listAdvancedOptions()
+				if errLi != nil {
+					return fmt.Errorf("error retrieving database options : %v", errLi)
+				}
+
+				req, errRe := advancedOptionsRequest[govultr.DatabaseAdvancedOptions](desired, avail)
+				if errRe != nil {
+					return errRe
+				}
+				o.AdvancedOptionsReq = req
+			}
 
 			// MySQL and PostgreSQL flags
 
@@ -4472,7 +5038,24 @@ func NewCmdDatabase(base *cli.Base) *cobra.Command { //nolint:funlen,gocyclo
 				)
 			}
 
+			desired, errDe := desiredAdvancedOptions(cmd, "Kafka REST advanced options update")
+			if errDe != nil {
+				return errDe
+			}
+
 			o.KafkaRESTAdvancedOptionsReq = &govultr.DatabaseKafkaRESTAdvancedOptions{}
+			if desired != nil {
+				_, avail, errLi := o.listAdvancedOptionsKafkaREST()
+				if errLi != nil {
+					return fmt.Errorf("error retrieving database Kafka REST options : %v", errLi)
+				}
+
+				req, errRe := advancedOptionsRequest[govultr.DatabaseKafkaRESTAdvancedOptions](desired, avail)
+				if errRe != nil {
+					return errRe
+				}
+				o.KafkaRESTAdvancedOptionsReq = req
+			}
 
 			if cmd.Flags().Changed("producer-acks") {
 				o.KafkaRESTAdvancedOptionsReq.ProducerAcks = producerAcks
@@ -4586,9 +5169,52 @@ func NewCmdDatabase(base *cli.Base) *cobra.Command { //nolint:funlen,gocyclo
 		"set the managed database Kafka REST configuration value for simpleconsumer_pool_size_max",
 	)
 
+	addAdvancedOptionFlags(advancedOptionKafkaRESTUpdate)
+
+	// Advanced Option Kafka REST Diff
+	advancedOptionKafkaRESTDiff := &cobra.Command{
+		Use:   "diff <Database ID>",
+		Short: "Compare the configured Kafka REST advanced options of a managed database with the desired ones",
+		Long:  advancedOptionDiffLong,
+		Args: func(cmd *cobra.Command, args []string) error {
+			if len(args) != 1 {
+				return errors.New("please provide a database ID")
+			}
+			return nil
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			desired, errDe := desiredAdvancedOptions(cmd, "Kafka REST advanced options diff")
+			if errDe != nil {
+				return errDe
+			}
+
+			if desired == nil {
+				return errNoDesiredOptions
+			}
+
+			cur, avail, err := o.listAdvancedOptionsKafkaREST()
+			if err != nil {
+				return fmt.Errorf("error retrieving database Kafka REST options : %v", err)
+			}
+
+			changes, err := diffAdvancedOptions(cur, desired, avail)
+			if err != nil {
+				return err
+			}
+
+			data := &AdvancedOptionsDiffPrinter{Changes: changes}
+			o.Base.Printer.Display(data, nil)
+
+			return nil
+		},
+	}
+
+	addAdvancedOptionFlags(advancedOptionKafkaRESTDiff)
+
 	advancedOptionKafkaREST.AddCommand(
 		advancedOptionKafkaRESTList,
 		advancedOptionKafkaRESTUpdate,
+		advancedOptionKafkaRESTDiff,
 	)
 
 	// Advanced Option Schema Registry
@@ -4655,7 +5281,24 @@ func NewCmdDatabase(base *cli.Base) *cobra.Command { //nolint:funlen,gocyclo
 				)
 			}
 
+			desired, errDe := desiredAdvancedOptions(cmd, "Schema Registry advanced options update")
+			if errDe != nil {
+				return errDe
+			}
+
 			o.SchemaRegistryAdvancedOptionsReq = &govultr.DatabaseSchemaRegistryAdvancedOptions{}
+			if desired != nil {
+				_, avail, errLi := o.listAdvancedOptionsSchemaRegistry()
+				if errLi != nil {
+					return fmt.Errorf("error retrieving database Schema Registry options : %v", errLi)
+				}
+
+				req, errRe := advancedOptionsRequest[govultr.DatabaseSchemaRegistryAdvancedOptions](desired, avail)
+				if errRe != nil {
+					return errRe
+				}
+				o.SchemaRegistryAdvancedOptionsReq = req
+			}
 
 			if cmd.Flags().Changed("leader-eligibility") {
 				o.SchemaRegistryAdvancedOptionsReq.LeaderEligibility = &leaderEligibility
@@ -4699,9 +5342,52 @@ func NewCmdDatabase(base *cli.Base) *cobra.Command { //nolint:funlen,gocyclo
 		"set the managed database Kafka REST configuration value for retriable_errors_silenced",
 	)
 
+	addAdvancedOptionFlags(advancedOptionSchemaRegistryUpdate)
+
+	// Advanced Option Schema Registry Diff
+	advancedOptionSchemaRegistryDiff := &cobra.Command{
+		Use:   "diff <Database ID>",
+		Short: "Compare the configured Schema Registry advanced options of a managed database with the desired ones",
+		Long:  advancedOptionDiffLong,
+		Args: func(cmd *cobra.Command, args []string) error {
+			if len(args) != 1 {
+				return errors.New("please provide a database ID")
+			}
+			return nil
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			desired, errDe := desiredAdvancedOptions(cmd, "Schema Registry advanced options diff")
+			if errDe != nil {
+				return errDe
+			}
+
+			if desired == nil {
+				return errNoDesiredOptions
+			}
+
+			cur, avail, err := o.listAdvancedOptionsSchemaRegistry()
+			if err != nil {
+				return fmt.Errorf("error retrieving database Schema Registry options : %v", err)
+			}
+
+			changes, err := diffAdvancedOptions(cur, desired, avail)
+			if err != nil {
+				return err
+			}
+
+			data := &AdvancedOptionsDiffPrinter{Changes: changes}
+			o.Base.Printer.Display(data, nil)
+
+			return nil
+		},
+	}
+
+	addAdvancedOptionFlags(advancedOptionSchemaRegistryDiff)
+
 	advancedOptionSchemaRegistry.AddCommand(
 		advancedOptionSchemaRegistryList,
 		advancedOptionSchemaRegistryUpdate,
+		advancedOptionSchemaRegistryDiff,
 	)
 
 	// Advanced Option Kafka Connect
@@ -4872,7 +5558,24 @@ func NewCmdDatabase(base *cli.Base) *cobra.Command { //nolint:funlen,gocyclo
 				)
 			}
 
+			desired, errDe := desiredAdvancedOptions(cmd, "Kafka Connect advanced options update")
+			if errDe != nil {
+				return errDe
+			}
+
 			o.KafkaConnectAdvancedOptionsReq = &govultr.DatabaseKafkaConnectAdvancedOptions{}
+			if desired != nil {
+				_, avail, errLi := o.listAdvancedOptionsKafkaConnect()
+				if errLi != nil {
+					return fmt.Errorf("error retrieving database Kafka Connect options : %v", errLi)
+				}
+
+				req, errRe := advancedOptionsRequest[govultr.DatabaseKafkaConnectAdvancedOptions](desired, avail)
+				if errRe != nil {
+					return errRe
+				}
+				o.KafkaConnectAdvancedOptionsReq = req
+			}
 
 			if cmd.Flags().Changed("connector-client-config-override-policy") {
 				o.KafkaConnectAdvancedOptionsReq.ConnectorClientConfigOverridePolicy = connectorClientConfigOverridePolicy
@@ -5046,14 +5749,100 @@ func NewCmdDatabase(base *cli.Base) *cobra.Command { //nolint:funlen,gocyclo
 		"set the managed database Kafka Connect configuration value for session_timeout_ms",
 	)
 
+	addAdvancedOptionFlags(advancedOptionKafkaConnectUpdate)
+
+	// Advanced Option Kafka Connect Diff
+	advancedOptionKafkaConnectDiff := &cobra.Command{
+		Use:   "diff <Database ID>",
+		Short: "Compare the configured Kafka Connect advanced options of a managed database with the desired ones",
+		Long:  advancedOptionDiffLong,
+		Args: func(cmd *cobra.Command, args []string) error {
+			if len(args) != 1 {
+				return errors.New("please provide a database ID")
+			}
+			return nil
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			desired, errDe := desiredAdvancedOptions(cmd, "Kafka Connect advanced options diff")
+			if errDe != nil {
+				return errDe
+			}
+
+			if desired == nil {
+				return errNoDesiredOptions
+			}
+
+			cur, avail, err := o.listAdvancedOptionsKafkaConnect()
+			if err != nil {
+				return fmt.Errorf("error retrieving database Kafka Connect options : %v", err)
+			}
+
+			changes, err := diffAdvancedOptions(cur, desired, avail)
+			if err != nil {
+				return err
+			}
+
+			data := &AdvancedOptionsDiffPrinter{Changes: changes}
+			o.Base.Printer.Display(data, nil)
+
+			return nil
+		},
+	}
+
+	addAdvancedOptionFlags(advancedOptionKafkaConnectDiff)
+
 	advancedOptionKafkaConnect.AddCommand(
 		advancedOptionKafkaConnectList,
 		advancedOptionKafkaConnectUpdate,
+		advancedOptionKafkaConnectDiff,
 	)
 
+	addAdvancedOptionFlags(advancedOptionUpdate)
+
+	// Advanced Option Diff
+	advancedOptionDiff := &cobra.Command{
+		Use:   "diff <Database ID>",
+		Short: "Compare the configured advanced options of a managed database with the desired ones",
+		Long:  advancedOptionDiffLong,
+		Args: func(cmd *cobra.Command, args []string) error {
+			if len(args) != 1 {
+				return errors.New("please provide a database ID")
+			}
+			return nil
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			desired, errDe := desiredAdvancedOptions(cmd, "advanced options diff")
+			if errDe != nil {
+				return errDe
+			}
+
+			if desired == nil {
+				return errNoDesiredOptions
+			}
+
+			cur, avail, err := o.listAdvancedOptions()
+			if err != nil {
+				return fmt.Errorf("error retrieving database options : %v", err)
+			}
+
+			changes, err := diffAdvancedOptions(cur, desired, avail)
+			if err != nil {
+				return err
+			}
+
+			data := &AdvancedOptionsDiffPrinter{Changes: changes}
+			o.Base.Printer.Display(data, nil)
+
+			return nil
+		},
+	}
+
+	addAdvancedOptionFlags(advancedOptionDiff)
+
 	advancedOption.AddCommand(
 		advancedOptionList,
 		advancedOptionUpdate,
+		advancedOptionDiff,
 		advancedOptionKafkaREST,
 		advancedOptionSchemaRegistry,
 		advancedOptionKafkaConnect,
@@ -5130,6 +5919,170 @@ func NewCmdDatabase(base *cli.Base) *cobra.Command { //nolint:funlen,gocyclo
 		versionUpgrade,
 	)
 
+	// Connection String
+	connectionString := &cobra.Command{
+		Use:     "connection-string <Database ID>",
+		Short:   "Print a connection string for a database",
+		Aliases: []string{"cs"},
+		Long:    connectionStringLong,
+		Example: connectionStringExample,
+		Args: func(cmd *cobra.Command, args []string) error {
+			if len(args) != 1 {
+				return errors.New("please provide a database ID")
+			}
+			return nil
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			format, errFo := cmd.Flags().GetString("format")
+			if errFo != nil {
+				return fmt.Errorf("error parsing flag 'format' for database connection-string : %v", errFo)
+			}
+
+			opts, err := connectionOptions(cmd, "connection-string")
+			if err != nil {
+				return err
+			}
+
+			conn, err := o.connection(opts)
+			if err != nil {
+				return err
+			}
+
+			out, err := conn.String(format)
+			if err != nil {
+				return err
+			}
+
+			fmt.Println(out)
+
+			return nil
+		},
+	}
+
+	connectionString.Flags().String(
+		"format",
+		formatURI,
+		fmt.Sprintf("(optional) format of the connection string. Possible values: %s", strings.Join(connectionFormats, ", ")),
+	)
+	addConnectionFlags(connectionString)
+
+	// Connect
+	connect := &cobra.Command{
+		Use:     "connect <Database ID> [-- <client arguments>]",
+		Short:   "Start the local client connected to a database",
+		Long:    connectLong,
+		Example: connectExample,
+		Args: func(cmd *cobra.Command, args []string) error {
+			if len(args) < 1 || cmd.ArgsLenAtDash() == 0 {
+				return errors.New("please provide a database ID")
+			}
+			return nil
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			client, errCl := cmd.Flags().GetString("client")
+			if errCl != nil {
+				return fmt.Errorf("error parsing flag 'client' for database connect : %v", errCl)
+			}
+
+			opts, err := connectionOptions(cmd, "connect")
+			if err != nil {
+				return err
+			}
+
+			conn, err := o.connection(opts)
+			if err != nil {
+				return err
+			}
+
+			clientCmd, err := conn.Command(client, args[1:])
+			if err != nil {
+				return err
+			}
+
+			if err := clientCmd.Run(); err != nil {
+				var exitErr *exec.ExitError
+				if errors.As(err, &exitErr) {
+					// the client already reported its error
+					cmd.SilenceErrors = true
+					return &utils.ExitError{Code: exitErr.ExitCode()}
+				}
+				return fmt.Errorf("error running the database client : %v", err)
+			}
+
+			return nil
+		},
+	}
+
+	connect.Flags().String("client", "", "(optional) the database client to run instead of the engine's default client")
+	addConnectionFlags(connect)
+
+	// Status
+	status := &cobra.Command{
+		Use:     "status <Database ID>",
+		Short:   "Display a health report of a database",
+		Long:    statusLong,
+		Example: statusExample,
+		Args: func(cmd *cobra.Command, args []string) error {
+			if len(args) != 1 {
+				return errors.New("please provide a database ID")
+			}
+			return nil
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			warning, errWa := cmd.Flags().GetFloat64("warning")
+			if errWa != nil {
+				return fmt.Errorf("error parsing flag 'warning' for status : %v", errWa)
+			}
+
+			critical, errCr := cmd.Flags().GetFloat64("critical")
+			if errCr != nil {
+				return fmt.Errorf("error parsing flag 'critical' for status : %v", errCr)
+			}
+
+			period, errPe := cmd.Flags().GetString("period")
+			if errPe != nil {
+				return fmt.Errorf("error parsing flag 'period' for status : %v", errPe)
+			}
+
+			if warning > critical {
+				return errors.New("the 'warning' threshold for status cannot be above the 'critical' threshold")
+			}
+
+			health, err := o.health(&HealthOptions{Warning: warning, Critical: critical, Period: period})
+			if err != nil {
+				return err
+			}
+
+			data := &HealthPrinter{Health: health}
+			o.Base.Printer.Print(data)
+
+			if code := health.ExitCode(); code != 0 {
+				// the report already shows the failed checks
+				cmd.SilenceErrors = true
+				return &utils.ExitError{Code: code}
+			}
+
+			return nil
+		},
+	}
+
+	status.Flags().Float64(
+		"warning",
+		healthDefaultWarning,
+		"(optional) disk, memory, CPU and connection utilization percentage reported as a warning",
+	)
+	status.Flags().Float64(
+		"critical",
+		healthDefaultCritical,
+		"(optional) disk, memory, CPU and connection utilization percentage reported as critical",
+	)
+	status.Flags().StringP(
+		"period",
+		"p",
+		"day",
+		"(optional) period (day, week, month, year) of the service alerts included in the report",
+	)
+
 	cmd.AddCommand(
 		list,
 		get,
@@ -5152,11 +6105,108 @@ func NewCmdDatabase(base *cli.Base) *cobra.Command { //nolint:funlen,gocyclo
 		connectionPool,
 		advancedOption,
 		version,
+		connectionString,
+		connect,
+		kafka,
+		status,
 	)
 
 	return cmd
 }
 
+// addPointInTimeFlags adds the flags selecting a point-in-time recovery
+// target and waiting for the new database
+func addPointInTimeFlags(cmd *cobra.Command) {
+	cmd.Flags().String(
+		"at",
+		"",
+		"(optional) point in time to recover to in the RFC 3339 format, such as 2026-10-01T12:00:00Z",
+	)
+	cmd.Flags().Duration("ago", 0, "(optional) point in time to recover to as a duration before now, such as 3h")
+	cmd.Flags().Bool("wait", false, "(optional) wait for the new database to be running")
+	cmd.Flags().Duration("timeout", restoreDefaultTimeout, "(optional) how long to wait for the new database")
+	cmd.MarkFlagsMutuallyExclusive("at", "ago")
+	cmd.MarkFlagsMutuallyExclusive("at", "date")
+	cmd.MarkFlagsMutuallyExclusive("at", "time")
+	cmd.MarkFlagsMutuallyExclusive("ago", "date")
+	cmd.MarkFlagsMutuallyExclusive("ago", "time")
+}
+
+// pointInTimeOptions parses the flags added by addPointInTimeFlags
+func pointInTimeOptions(cmd *cobra.Command, name string) (*PointInTimeOptions, error) {
+	at, errAt := cmd.Flags().GetString("at")
+	if errAt != nil {
+		return nil, fmt.Errorf("error parsing flag 'at' for %s : %v", name, errAt)
+	}
+
+	ago, errAg := cmd.Flags().GetDuration("ago")
+	if errAg != nil {
+		return nil, fmt.Errorf("error parsing flag 'ago' for %s : %v", name, errAg)
+	}
+
+	wait, errWa := cmd.Flags().GetBool("wait")
+	if errWa != nil {
+		return nil, fmt.Errorf("error parsing flag 'wait' for %s : %v", name, errWa)
+	}
+
+	timeout, errTi := cmd.Flags().GetDuration("timeout")
+	if errTi != nil {
+		return nil, fmt.Errorf("error parsing flag 'timeout' for %s : %v", name, errTi)
+	}
+
+	if cmd.Flags().Changed("ago") && ago <= 0 {
+		return nil, fmt.Errorf("the 'ago' flag for %s must be a positive duration", name)
+	}
+
+	return &PointInTimeOptions{At: at, Ago: ago, Wait: wait, Timeout: timeout}, nil
+}
+
+// addConnectionFlags adds the flags selecting the connection details
+func addConnectionFlags(cmd *cobra.Command) {
+	cmd.Flags().String("user", "", "(optional) the database user to connect as. Defaults to the admin user")
+	cmd.Flags().String("db", "", "(optional) the logical database to connect to. Defaults to the default database")
+	cmd.Flags().String("pool", "", "(optional) the PostgreSQL connection pool to connect through")
+	cmd.Flags().String("replica", "", "(optional) the ID or label of the read replica to connect to")
+	cmd.Flags().Bool("public", false, "(optional) connect to the public host of a database in a VPC")
+	cmd.MarkFlagsMutuallyExclusive("db", "pool")
+}
+
+// connectionOptions parses the flags added by addConnectionFlags
+func connectionOptions(cmd *cobra.Command, name string) (*ConnectionOptions, error) {
+	user, errUs := cmd.Flags().GetString("user")
+	if errUs != nil {
+		return nil, fmt.Errorf("error parsing flag 'user' for database %s : %v", name, errUs)
+	}
+
+	dbName, errDb := cmd.Flags().GetString("db")
+	if errDb != nil {
+		return nil, fmt.Errorf("error parsing flag 'db' for database %s : %v", name, errDb)
+	}
+
+	pool, errPo := cmd.Flags().GetString("pool")
+	if errPo != nil {
+		return nil, fmt.Errorf("error parsing flag 'pool' for database %s : %v", name, errPo)
+	}
+
+	replica, errRe := cmd.Flags().GetString("replica")
+	if errRe != nil {
+		return nil, fmt.Errorf("error parsing flag 'replica' for database %s : %v", name, errRe)
+	}
+
+	public, errPu := cmd.Flags().GetBool("public")
+	if errPu != nil {
+		return nil, fmt.Errorf("error parsing flag 'public' for database %s : %v", name, errPu)
+	}
+
+	return &ConnectionOptions{
+		User:     user,
+		Database: dbName,
+		Pool:     pool,
+		Replica:  replica,
+		Public:   public,
+	}, nil
+}
+
 type options struct {
 	Base                             *cli.Base
 	CreateReq                        *govultr.DatabaseCreateReq
@@ -5200,6 +6250,18 @@ func (o *options) create() (*govultr.Database, error) {
 	return db, err
 }
 
+// estimate displays the estimated cost of the database in the create request
+func (o *options) estimate() error {
+	item, err := cost.NewEstimator(o.Base).Database(o.CreateReq.Plan, o.CreateReq.Region, 1)
+	if err != nil {
+		return fmt.Errorf("error estimating database cost : %v", err)
+	}
+
+	o.Base.Printer.Display(&cost.EstimatePrinter{Estimate: cost.NewEstimate(item)}, nil)
+
+	return nil
+}
+
 func (o *options) update() (*govultr.Database, error) {
 	db, _, err := o.Base.Client.Database.Update(o.Base.Context, o.Base.Args[0], o.UpdateReq)
 	return db, err
@@ -0,0 +1,281 @@
+package database
+
+import (
+	"fmt"
+	"sync"
+
+	"github.com/vultr/govultr/v3"
+	"github.com/vultr/vultr-cli/v3/cmd/utils"
+)
+
+const (
+	healthOK       string = "OK"
+	healthWarning  string = "WARNING"
+	healthCritical string = "CRITICAL"
+	healthUnknown  string = "UNKNOWN"
+
+	healthDefaultWarning  float64 = 80
+	healthDefaultCritical float64 = 90
+)
+
+// healthExitCodes are the exit codes of the health levels, following the
+// convention of monitoring plugins
+var healthExitCodes = map[string]int{
+	healthOK:       0,
+	healthWarning:  1,
+	healthCritical: 2,
+	healthUnknown:  3,
+}
+
+// healthSeverities rank the health levels for the overall level.  Unknown has
+// the highest exit code but a critical check outranks it, so 3 is only used
+// when nothing was critical.
+var healthSeverities = map[string]int{
+	healthOK:       0,
+	healthWarning:  1,
+	healthUnknown:  2,
+	healthCritical: 3,
+}
+
+// HealthOptions holds the utilization thresholds in percent and the period of
+// the alerts included in the report
+type HealthOptions struct {
+	Warning  float64
+	Critical float64
+	Period   string
+}
+
+// HealthCheck is a single check of a database health report
+type HealthCheck struct {
+	Name   string `json:"name"`
+	Level  string `json:"level"`
+	Detail string `json:"detail"`
+}
+
+// ReplicaHealth is the state of a read replica
+type ReplicaHealth struct {
+	ID     string                 `json:"id"`
+	Label  string                 `json:"label"`
+	Region string                 `json:"region"`
+	Status string                 `json:"status"`
+	Usage  *govultr.DatabaseUsage `json:"usage,omitempty"`
+}
+
+// DatabaseHealth is the aggregated health report of a managed database
+type DatabaseHealth struct {
+	ID          string                           `json:"id"`
+	Label       string                           `json:"label"`
+	Engine      string                           `json:"engine"`
+	Status      string                           `json:"status"`
+	Level       string                           `json:"level"`
+	Usage       *govultr.DatabaseUsage           `json:"usage,omitempty"`
+	Maintenance []string                         `json:"maintenance_updates"`
+	Alerts      []govultr.DatabaseAlert          `json:"alerts"`
+	Replicas    []ReplicaHealth                  `json:"replicas"`
+	Connections *govultr.DatabaseConnections     `json:"connections,omitempty"`
+	Pools       []govultr.DatabaseConnectionPool `json:"connection_pools,omitempty"`
+	Checks      []HealthCheck                    `json:"checks"`
+}
+
+// ExitCode returns the exit code of the overall health level
+func (h *DatabaseHealth) ExitCode() int {
+	return healthExitCodes[h.Level]
+}
+
+func (h *DatabaseHealth) add(name, level, detail string) {
+	h.Checks = append(h.Checks, HealthCheck{Name: name, Level: level, Detail: detail})
+	if healthSeverities[level] > healthSeverities[h.Level] {
+		h.Level = level
+	}
+}
+
+// utilizationLevel returns the level of a utilization percentage
+func utilizationLevel(pct float64, opts *HealthOptions) string {
+	switch {
+	case pct >= opts.Critical:
+		return healthCritical
+	case pct >= opts.Warning:
+		return healthWarning
+	default:
+		return healthOK
+	}
+}
+
+// statusLevel returns the level of a database or replica status
+func statusLevel(status string) string {
+	if status == dbStatusRunning {
+		return healthOK
+	}
+	return healthCritical
+}
+
+// health retrieves the database and then its usage, maintenance updates,
+// alerts, replica usage and connection pools concurrently.  Failed requests
+// are reported as unknown checks rather than failing the whole report, and a
+// failure to retrieve the database exits with the unknown code.
+func (o *options) health(opts *HealthOptions) (*DatabaseHealth, error) { //nolint:funlen,gocyclo
+	db, _, err := o.Base.Client.Database.Get(o.Base.Context, o.Base.Args[0])
+	if err != nil {
+		return nil, &utils.ExitError{
+			Code: healthExitCodes[healthUnknown],
+			Err:  fmt.Errorf("error retrieving database : %v", err),
+		}
+	}
+
+	h := &DatabaseHealth{
+		ID:          db.ID,
+		Label:       db.Label,
+		Engine:      db.DatabaseEngine,
+		Status:      db.Status,
+		Level:       healthOK,
+		Maintenance: []string{},
+		Alerts:      []govultr.DatabaseAlert{},
+		Replicas:    make([]ReplicaHealth, len(db.ReadReplicas)),
+	}
+
+	var (
+		wg                                      sync.WaitGroup
+		errUsage, errMaint, errAlerts, errPools error
+		replicaErrs                             = make([]error, len(db.ReadReplicas))
+		checkPools                              = db.DatabaseEngine == enginePG
+		maintenance                             []string
+		alerts                                  []govultr.DatabaseAlert
+		connections                             *govultr.DatabaseConnections
+		pools                                   []govultr.DatabaseConnectionPool
+	)
+
+	wg.Add(3)
+	go func() {
+		defer wg.Done()
+		h.Usage, _, errUsage = o.Base.Client.Database.GetUsage(o.Base.Context, db.ID)
+	}()
+	go func() {
+		defer wg.Done()
+		maintenance, _, errMaint = o.Base.Client.Database.ListMaintenanceUpdates(o.Base.Context, db.ID)
+	}()
+	go func() {
+		defer wg.Done()
+		alerts, _, errAlerts = o.Base.Client.Database.ListServiceAlerts(
+			o.Base.Context,
+			db.ID,
+			&govultr.DatabaseListAlertsReq{Period: opts.Period},
+		)
+	}()
+
+	if checkPools {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			connections, pools, _, _, errPools = o.Base.Client.Database.ListConnectionPools(o.Base.Context, db.ID)
+		}()
+	}
+
+	for i := range db.ReadReplicas {
+		r := &db.ReadReplicas[i]
+		h.Replicas[i] = ReplicaHealth{ID: r.ID, Label: r.Label, Region: r.Region, Status: r.Status}
+
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			h.Replicas[i].Usage, _, replicaErrs[i] = o.Base.Client.Database.GetUsage(o.Base.Context, h.Replicas[i].ID)
+		}(i)
+	}
+
+	wg.Wait()
+
+	h.add("status", statusLevel(db.Status), db.Status)
+
+	if errUsage != nil {
+		h.add("usage", healthUnknown, fmt.Sprintf("error retrieving usage : %v", errUsage))
+	} else {
+		h.addUsage("", h.Usage, opts)
+	}
+
+	if errMaint != nil {
+		h.add("maintenance", healthUnknown, fmt.Sprintf("error retrieving maintenance updates : %v", errMaint))
+	} else {
+		if maintenance != nil {
+			h.Maintenance = maintenance
+		}
+
+		level := healthOK
+		if len(h.Maintenance) > 0 {
+			level = healthWarning
+		}
+		h.add("maintenance", level, fmt.Sprintf("%d pending updates", len(h.Maintenance)))
+	}
+
+	if errAlerts != nil {
+		h.add("alerts", healthUnknown, fmt.Sprintf("error retrieving alerts : %v", errAlerts))
+	} else {
+		if alerts != nil {
+			h.Alerts = alerts
+		}
+
+		level := healthOK
+		if len(h.Alerts) > 0 {
+			level = healthWarning
+		}
+		h.add("alerts", level, fmt.Sprintf("%d alerts in the last %s", len(h.Alerts), opts.Period))
+	}
+
+	// The API does not report replication lag so replicas are checked on
+	// their status and usage
+	for i := range h.Replicas {
+		r := &h.Replicas[i]
+		name := fmt.Sprintf("replica %s", r.Label)
+		if r.Label == "" {
+			name = fmt.Sprintf("replica %s", r.ID)
+		}
+		h.add(name+" status", statusLevel(r.Status), r.Status)
+
+		if replicaErrs[i] != nil {
+			h.add(name+" usage", healthUnknown, fmt.Sprintf("error retrieving usage : %v", replicaErrs[i]))
+			continue
+		}
+		h.addUsage(name+" ", r.Usage, opts)
+	}
+
+	if checkPools {
+		if errPools != nil {
+			h.add("connections", healthUnknown, fmt.Sprintf("error retrieving connection pools : %v", errPools))
+		} else if connections != nil {
+			h.Connections = connections
+			h.Pools = pools
+
+			var pct float64
+			if connections.Max > 0 {
+				pct = float64(connections.Used) / float64(connections.Max) * 100 //nolint:mnd
+			}
+
+			h.add(
+				"connections",
+				utilizationLevel(pct, opts),
+				fmt.Sprintf("%.2f%% (%d of %d used)", pct, connections.Used, connections.Max),
+			)
+		}
+	}
+
+	return h, nil
+}
+
+// addUsage adds the disk, memory and CPU checks of the usage
+func (h *DatabaseHealth) addUsage(prefix string, usage *govultr.DatabaseUsage, opts *HealthOptions) {
+	if usage == nil {
+		return
+	}
+
+	metrics := []struct {
+		name string
+		pct  float32
+	}{
+		{"disk", usage.Disk.Percentage},
+		{"memory", usage.Memory.Percentage},
+		{"cpu", usage.CPU.Percentage},
+	}
+
+	for i := range metrics {
+		pct := float64(metrics[i].pct)
+		h.add(prefix+metrics[i].name, utilizationLevel(pct, opts), fmt.Sprintf("%.2f%%", pct))
+	}
+}
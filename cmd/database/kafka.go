@@ -0,0 +1,458 @@
+package database
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/vultr/govultr/v3"
+	"gopkg.in/yaml.v3"
+)
+
+const (
+	kafkaTypeTopic string = "topic"
+	kafkaTypeUser  string = "user"
+	kafkaTypeQuota string = "quota"
+)
+
+var kafkaPermissions = []string{"admin", "read", "write", "readwrite"}
+
+// KafkaSpec is the document of Kafka resources used by kafka apply.  Only the
+// sections present in the document are reconciled, so a missing section
+// leaves those resources alone while an empty one removes them all.
+type KafkaSpec struct {
+	Topics []KafkaTopicSpec `yaml:"topics"`
+	Users  []KafkaUserSpec  `yaml:"users"`
+	Quotas []KafkaQuotaSpec `yaml:"quotas"`
+}
+
+// KafkaTopicSpec is a topic of a KafkaSpec
+type KafkaTopicSpec struct {
+	Name           string `yaml:"name"`
+	Partitions     int    `yaml:"partitions"`
+	Replication    int    `yaml:"replication"`
+	RetentionHours int    `yaml:"retention_hours"`
+	RetentionBytes int    `yaml:"retention_bytes"`
+}
+
+// KafkaUserSpec is a user of a KafkaSpec.  The password is optional and
+// generated by the API when omitted.
+type KafkaUserSpec struct {
+	Username   string `yaml:"username"`
+	Permission string `yaml:"permission"`
+	Password   string `yaml:"password,omitempty"`
+}
+
+// KafkaQuotaSpec is a client quota of a KafkaSpec, matched on the client ID
+// and user
+type KafkaQuotaSpec struct {
+	ClientID          string `yaml:"client_id"`
+	User              string `yaml:"user"`
+	ConsumerByteRate  int    `yaml:"consumer_byte_rate"`
+	ProducerByteRate  int    `yaml:"producer_byte_rate"`
+	RequestPercentage int    `yaml:"request_percentage"`
+}
+
+// KafkaChange is a planned or applied change to a Kafka resource
+type KafkaChange struct {
+	Action  string `json:"action"`
+	Type    string `json:"type"`
+	Name    string `json:"name"`
+	Details string `json:"details"`
+
+	// quota holds the client ID and user of a quota change
+	quota KafkaQuotaSpec
+}
+
+func (t *KafkaTopicSpec) String() string {
+	return fmt.Sprintf(
+		"partitions: %d, replication: %d, retention hours: %d, retention bytes: %d",
+		t.Partitions,
+		t.Replication,
+		t.RetentionHours,
+		t.RetentionBytes,
+	)
+}
+
+func (q *KafkaQuotaSpec) key() string {
+	return q.ClientID + "/" + q.User
+}
+
+func (q *KafkaQuotaSpec) String() string {
+	return fmt.Sprintf(
+		"consumer byte rate: %d, producer byte rate: %d, request percentage: %d",
+		q.ConsumerByteRate,
+		q.ProducerByteRate,
+		q.RequestPercentage,
+	)
+}
+
+// NewKafkaSpecFromFile reads in a YAML or JSON Kafka spec file.  Unknown
+// fields are an error to catch typos before calling the API.
+func NewKafkaSpecFromFile(path string) (*KafkaSpec, error) {
+	fd, err := os.ReadFile(filepath.Clean(path))
+	if err != nil {
+		return nil, fmt.Errorf("error reading kafka spec file : %v", err)
+	}
+
+	dec := yaml.NewDecoder(bytes.NewReader(fd))
+	dec.KnownFields(true)
+
+	spec := &KafkaSpec{}
+	if err := dec.Decode(spec); err != nil {
+		return nil, fmt.Errorf("error parsing kafka spec file : %v", err)
+	}
+
+	return spec, spec.validate()
+}
+
+// validate checks the spec locally without calling the API
+func (s *KafkaSpec) validate() error { //nolint:gocyclo
+	var errs []string
+	addErr := func(format string, a ...interface{}) {
+		errs = append(errs, fmt.Sprintf(format, a...))
+	}
+
+	names := map[string]bool{}
+	for i := range s.Topics {
+		t := &s.Topics[i]
+		if t.Name == "" {
+			addErr("topics[%d] name is required", i)
+		} else if names[t.Name] {
+			addErr("topics[%d] name %s is used more than once", i, t.Name)
+		}
+		names[t.Name] = true
+
+		if t.Partitions < 1 || t.Replication < 1 {
+			addErr("topics[%d] partitions and replication must be at least 1", i)
+		}
+	}
+
+	users := map[string]bool{}
+	for i := range s.Users {
+		u := &s.Users[i]
+		if u.Username == "" {
+			addErr("users[%d] username is required", i)
+		} else if users[u.Username] {
+			addErr("users[%d] username %s is used more than once", i, u.Username)
+		}
+		users[u.Username] = true
+
+		if !slices.Contains(kafkaPermissions, u.Permission) {
+			addErr("users[%d] permission must be one of %s", i, strings.Join(kafkaPermissions, ", "))
+		}
+	}
+
+	quotas := map[string]bool{}
+	for i := range s.Quotas {
+		q := &s.Quotas[i]
+		if q.ClientID == "" && q.User == "" {
+			addErr("quotas[%d] requires a client_id or a user", i)
+		} else if quotas[q.key()] {
+			addErr("quotas[%d] client_id and user are used by more than one quota", i)
+		}
+		quotas[q.key()] = true
+	}
+
+	if len(errs) > 0 {
+		return fmt.Errorf("invalid kafka spec :\n  %s", strings.Join(errs, "\n  "))
+	}
+
+	return nil
+}
+
+// planKafka returns the changes needed to converge the current resources on
+// the spec.  The admin user is never removed.
+func planKafka( //nolint:funlen,gocyclo
+	spec *KafkaSpec,
+	admin string,
+	topics []govultr.DatabaseTopic,
+	users []govultr.DatabaseUser,
+	quotas []govultr.DatabaseQuota,
+) ([]KafkaChange, error) {
+	var changes []KafkaChange
+
+	if spec.Topics != nil {
+		current := map[string]*govultr.DatabaseTopic{}
+		for i := range topics {
+			current[topics[i].Name] = &topics[i]
+		}
+
+		for i := range spec.Topics {
+			want := &spec.Topics[i]
+			change := KafkaChange{Action: syncActionCreate, Type: kafkaTypeTopic, Name: want.Name, Details: want.String()}
+
+			if cur, ok := current[want.Name]; ok {
+				delete(current, want.Name)
+
+				if want.Partitions < cur.Partitions {
+					return nil, fmt.Errorf(
+						"topic %s cannot be reduced from %d to %d partitions",
+						want.Name,
+						cur.Partitions,
+						want.Partitions,
+					)
+				}
+
+				change.Action = syncActionUnchanged
+				if cur.Partitions != want.Partitions ||
+					cur.Replication != want.Replication ||
+					cur.RetentionHours != want.RetentionHours ||
+					cur.RetentionBytes != want.RetentionBytes {
+					change.Action = syncActionUpdate
+				}
+			}
+			changes = append(changes, change)
+		}
+
+		for i := range topics {
+			if _, ok := current[topics[i].Name]; ok {
+				changes = append(changes, KafkaChange{Action: syncActionDelete, Type: kafkaTypeTopic, Name: topics[i].Name})
+			}
+		}
+	}
+
+	if spec.Users != nil {
+		current := map[string]*govultr.DatabaseUser{}
+		for i := range users {
+			current[users[i].Username] = &users[i]
+		}
+
+		for i := range spec.Users {
+			want := &spec.Users[i]
+			change := KafkaChange{
+				Action:  syncActionCreate,
+				Type:    kafkaTypeUser,
+				Name:    want.Username,
+				Details: "permission: " + want.Permission,
+			}
+
+			if cur, ok := current[want.Username]; ok {
+				delete(current, want.Username)
+
+				change.Action = syncActionUnchanged
+				if cur.Permission != want.Permission || want.Password != "" && cur.Password != want.Password {
+					change.Action = syncActionUpdate
+				}
+			}
+			changes = append(changes, change)
+		}
+
+		for i := range users {
+			if _, ok := current[users[i].Username]; ok && users[i].Username != admin {
+				changes = append(changes, KafkaChange{Action: syncActionDelete, Type: kafkaTypeUser, Name: users[i].Username})
+			}
+		}
+	}
+
+	if spec.Quotas != nil {
+		current := map[string]*govultr.DatabaseQuota{}
+		for i := range quotas {
+			q := KafkaQuotaSpec{ClientID: quotas[i].ClientID, User: quotas[i].User}
+			current[q.key()] = &quotas[i]
+		}
+
+		for i := range spec.Quotas {
+			want := &spec.Quotas[i]
+			change := KafkaChange{
+				Action:  syncActionCreate,
+				Type:    kafkaTypeQuota,
+				Name:    want.key(),
+				Details: want.String(),
+				quota:   *want,
+			}
+
+			if cur, ok := current[want.key()]; ok {
+				delete(current, want.key())
+
+				change.Action = syncActionUnchanged
+				if cur.ConsumerByteRate != want.ConsumerByteRate ||
+					cur.ProducerByteRate != want.ProducerByteRate ||
+					cur.RequestPercentage != want.RequestPercentage {
+					change.Action = syncActionUpdate
+				}
+			}
+			changes = append(changes, change)
+		}
+
+		for i := range quotas {
+			q := KafkaQuotaSpec{ClientID: quotas[i].ClientID, User: quotas[i].User}
+			if _, ok := current[q.key()]; ok {
+				changes = append(changes, KafkaChange{Action: syncActionDelete, Type: kafkaTypeQuota, Name: q.key(), quota: q})
+			}
+		}
+	}
+
+	return changes, nil
+}
+
+// applyKafka converges the topics, users and quotas of a Kafka database on
+// the spec.  Resources are created and updated before any are deleted, and
+// quotas are deleted before the users they may refer to.  On an error the
+// changes applied so far are returned.
+func (o *options) applyKafka(spec *KafkaSpec, dryRun bool) ([]KafkaChange, error) { //nolint:funlen,gocyclo
+	db, err := o.get()
+	if err != nil {
+		return nil, fmt.Errorf("error retrieving database : %v", err)
+	}
+
+	if db.DatabaseEngine != engineKafka {
+		return nil, fmt.Errorf("database %s is not a kafka database", db.ID)
+	}
+
+	topics, _, _, err := o.Base.Client.Database.ListTopics(o.Base.Context, o.Base.Args[0])
+	if err != nil {
+		return nil, fmt.Errorf("error retrieving topics : %v", err)
+	}
+
+	users, _, _, err := o.Base.Client.Database.ListUsers(o.Base.Context, o.Base.Args[0])
+	if err != nil {
+		return nil, fmt.Errorf("error retrieving users : %v", err)
+	}
+
+	quotas, _, _, err := o.Base.Client.Database.ListQuotas(o.Base.Context, o.Base.Args[0])
+	if err != nil {
+		return nil, fmt.Errorf("error retrieving quotas : %v", err)
+	}
+
+	changes, err := planKafka(spec, db.User, topics, users, quotas)
+	if err != nil {
+		return nil, err
+	}
+
+	if dryRun {
+		return changes, nil
+	}
+
+	topicSpecs := map[string]*KafkaTopicSpec{}
+	for i := range spec.Topics {
+		topicSpecs[spec.Topics[i].Name] = &spec.Topics[i]
+	}
+
+	userSpecs := map[string]*KafkaUserSpec{}
+	for i := range spec.Users {
+		userSpecs[spec.Users[i].Username] = &spec.Users[i]
+	}
+
+	// Deletions run last, in reverse order of the resource types
+	var deletions, applied []KafkaChange
+	for i := range changes {
+		if changes[i].Action == syncActionDelete {
+			deletions = append([]KafkaChange{changes[i]}, deletions...)
+			continue
+		}
+
+		if err := o.applyKafkaChange(&changes[i], topicSpecs, userSpecs); err != nil {
+			return applied, err
+		}
+		applied = append(applied, changes[i])
+	}
+
+	for i := range deletions {
+		if err := o.applyKafkaChange(&deletions[i], topicSpecs, userSpecs); err != nil {
+			return applied, err
+		}
+		applied = append(applied, deletions[i])
+	}
+
+	return changes, nil
+}
+
+// confirmKafkaDeletes plans the spec and prompts for confirmation when
+// topics, users or quotas would be deleted
+func (o *options) confirmKafkaDeletes(spec *KafkaSpec) error {
+	plan, err := o.applyKafka(spec, true)
+	if err != nil {
+		return fmt.Errorf("error planning kafka spec : %v", err)
+	}
+
+	var deletes []string
+	for i := range plan {
+		if plan[i].Action == syncActionDelete {
+			deletes = append(deletes, plan[i].Type+" "+plan[i].Name)
+		}
+	}
+
+	return confirmSyncDeletes(deletes)
+}
+
+// applyKafkaChange makes a single planned change
+func (o *options) applyKafkaChange( //nolint:gocyclo
+	change *KafkaChange,
+	topics map[string]*KafkaTopicSpec,
+	users map[string]*KafkaUserSpec,
+) error {
+	ctx, id := o.Base.Context, o.Base.Args[0]
+	db := o.Base.Client.Database
+
+	var err error
+	switch change.Type {
+	case kafkaTypeTopic:
+		t := topics[change.Name]
+		switch change.Action {
+		case syncActionCreate:
+			_, _, err = db.CreateTopic(ctx, id, &govultr.DatabaseTopicCreateReq{
+				Name:           t.Name,
+				Partitions:     t.Partitions,
+				Replication:    t.Replication,
+				RetentionHours: t.RetentionHours,
+				RetentionBytes: t.RetentionBytes,
+			})
+		case syncActionUpdate:
+			_, _, err = db.UpdateTopic(ctx, id, t.Name, &govultr.DatabaseTopicUpdateReq{
+				Partitions:     t.Partitions,
+				Replication:    t.Replication,
+				RetentionHours: t.RetentionHours,
+				RetentionBytes: t.RetentionBytes,
+			})
+		case syncActionDelete:
+			err = db.DeleteTopic(ctx, id, change.Name)
+		}
+	case kafkaTypeUser:
+		u := users[change.Name]
+		switch change.Action {
+		case syncActionCreate:
+			_, _, err = db.CreateUser(ctx, id, &govultr.DatabaseUserCreateReq{
+				Username:   u.Username,
+				Password:   u.Password,
+				Permission: u.Permission,
+			})
+		case syncActionUpdate:
+			_, _, err = db.UpdateUserACL(ctx, id, u.Username, &govultr.DatabaseUserACLReq{Permission: u.Permission})
+			if err == nil && u.Password != "" {
+				_, _, err = db.UpdateUser(ctx, id, u.Username, &govultr.DatabaseUserUpdateReq{Password: u.Password})
+			}
+		case syncActionDelete:
+			err = db.DeleteUser(ctx, id, change.Name)
+		}
+	case kafkaTypeQuota:
+		q := &change.quota
+		switch change.Action {
+		case syncActionCreate:
+			_, _, err = db.CreateQuota(ctx, id, &govultr.DatabaseQuotaCreateReq{
+				ClientID:          q.ClientID,
+				User:              q.User,
+				ConsumerByteRate:  q.ConsumerByteRate,
+				ProducerByteRate:  q.ProducerByteRate,
+				RequestPercentage: q.RequestPercentage,
+			})
+		case syncActionUpdate:
+			_, _, err = db.UpdateQuota(ctx, id, q.ClientID, q.User, &govultr.DatabaseQuotaUpdateReq{
+				ConsumerByteRate:  q.ConsumerByteRate,
+				ProducerByteRate:  q.ProducerByteRate,
+				RequestPercentage: q.RequestPercentage,
+			})
+		case syncActionDelete:
+			err = db.DeleteQuota(ctx, id, q.ClientID, q.User)
+		}
+	}
+
+	if err != nil {
+		return fmt.Errorf("error applying %s of %s %s : %v", change.Action, change.Type, change.Name, err)
+	}
+
+	return nil
+}
@@ -0,0 +1,127 @@
+package database
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/vultr/govultr/v3"
+)
+
+const (
+	migrationStatusRunning string = "running"
+	migrationStatusSyncing string = "syncing"
+	migrationStatusDone    string = "done"
+	migrationStatusFailed  string = "failed"
+
+	migrationDefaultTimeout = 2 * time.Hour
+	migrationPollInterval   = 10 * time.Second
+)
+
+// readMigrationPassword reads the source password from a file, or from stdin
+// when the path is "-".  Only the first line is used so a trailing newline
+// is not taken as part of the password.
+func readMigrationPassword(path string, stdin io.Reader) (string, error) {
+	in := stdin
+	if path != "-" {
+		fd, err := os.Open(filepath.Clean(path))
+		if err != nil {
+			return "", fmt.Errorf("error opening password file : %v", err)
+		}
+		defer fd.Close() //nolint:errcheck
+		in = fd
+	}
+
+	line, err := bufio.NewReader(in).ReadString('\n')
+	if err != nil && err != io.EOF {
+		return "", fmt.Errorf("error reading password : %v", err)
+	}
+
+	password := strings.TrimRight(line, "\r\n")
+	if password == "" {
+		return "", errors.New("the password is empty")
+	}
+
+	return password, nil
+}
+
+// migrationCopied returns whether the migration has copied the source data.
+// Dump migrations are then done while replication migrations keep syncing
+// changes from the source until they are detached.  Syncing only means the
+// initial copy is done, the API does not report how far replication lags.
+func migrationCopied(mig *govultr.DatabaseMigration) bool {
+	return mig.Status == migrationStatusDone || mig.Status == migrationStatusSyncing
+}
+
+// migrationProgress describes the state of the migration on a single line
+func migrationProgress(mig *govultr.DatabaseMigration, elapsed time.Duration) string {
+	line := fmt.Sprintf("migration %s", mig.Status)
+	if mig.Method != "" {
+		line += fmt.Sprintf(" (method: %s)", mig.Method)
+	}
+	return fmt.Sprintf("%s, elapsed: %s", line, elapsed.Truncate(time.Second))
+}
+
+// isTerminal returns whether the file is a character device such as a
+// terminal rather than a pipe or regular file
+func isTerminal(f *os.File) bool {
+	fi, err := f.Stat()
+	if err != nil {
+		return false
+	}
+	return fi.Mode()&os.ModeCharDevice != 0
+}
+
+// waitMigration polls the migration status until it has copied the source
+// data, printing the progress to stderr.  The progress is redrawn in place
+// on a terminal and printed on a new line for each change otherwise.
+func (o *options) waitMigration(timeout time.Duration) (*govultr.DatabaseMigration, error) {
+	start := time.Now()
+	live := isTerminal(os.Stderr)
+	var last string
+
+	defer func() {
+		if live && last != "" {
+			fmt.Fprintln(os.Stderr)
+		}
+	}()
+
+	for {
+		mig, err := o.getMigrationStatus()
+		if err != nil {
+			return nil, fmt.Errorf("error retrieving database migration status : %v", err)
+		}
+
+		if mig.Status == migrationStatusFailed || mig.Error != "" {
+			msg := mig.Error
+			if msg == "" {
+				msg = "no error was reported"
+			}
+			return mig, fmt.Errorf("migration %s : %s", mig.Status, msg)
+		}
+
+		progress := migrationProgress(mig, time.Since(start))
+		switch {
+		case live:
+			fmt.Fprintf(os.Stderr, "\r\033[K%s", progress)
+		case mig.Status != last:
+			fmt.Fprintln(os.Stderr, progress)
+		}
+		last = mig.Status
+
+		if migrationCopied(mig) {
+			return mig, nil
+		}
+
+		if time.Since(start) > timeout {
+			return mig, fmt.Errorf("timed out after %s waiting for the migration, status: %s", timeout, mig.Status)
+		}
+
+		time.Sleep(migrationPollInterval)
+	}
+}
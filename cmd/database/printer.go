@@ -5,6 +5,7 @@ import (
 	"reflect"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/vultr/govultr/v3"
 	"github.com/vultr/vultr-cli/v3/cmd/printer"
@@ -1446,6 +1447,15 @@ func (b *BackupPrinter) Data() [][]string {
 		[]string{"TIME", b.Backup.OldestBackup.Time},
 	)
 
+	if oldest, err := parseBackupTime(&b.Backup.OldestBackup); err == nil {
+		data = append(data,
+			[]string{" "},
+			[]string{"POINT-IN-TIME WINDOW"},
+			[]string{"FROM", oldest.Format(time.RFC3339)},
+			[]string{"TO", "now"},
+		)
+	}
+
 	return data
 }
 
@@ -2249,3 +2259,273 @@ func (c *ConnectorStatusPrinter) Data() [][]string {
 func (c *ConnectorStatusPrinter) Paging() [][]string {
 	return nil
 }
+
+// ======================================
+
+// AdvancedOptionsDiffPrinter ...
+type AdvancedOptionsDiffPrinter struct {
+	Changes []AdvancedOptionChange `json:"changes"`
+}
+
+// JSON ...
+func (a *AdvancedOptionsDiffPrinter) JSON() []byte {
+	return printer.MarshalObject(a, "json")
+}
+
+// YAML ...
+func (a *AdvancedOptionsDiffPrinter) YAML() []byte {
+	return printer.MarshalObject(a, "yaml")
+}
+
+// Columns ...
+func (a *AdvancedOptionsDiffPrinter) Columns() [][]string {
+	return [][]string{0: {
+		"NAME",
+		"CURRENT",
+		"DESIRED",
+		"ALLOWED",
+		"ACTION",
+	}}
+}
+
+// Data ...
+func (a *AdvancedOptionsDiffPrinter) Data() [][]string {
+	if len(a.Changes) == 0 {
+		return [][]string{0: {"---", "---", "---", "---", "---"}}
+	}
+
+	var data [][]string
+	for i := range a.Changes {
+		data = append(data, []string{
+			a.Changes[i].Name,
+			a.Changes[i].Current,
+			a.Changes[i].Desired,
+			a.Changes[i].Allowed,
+			a.Changes[i].Action,
+		})
+	}
+
+	return data
+}
+
+// Paging ...
+func (a *AdvancedOptionsDiffPrinter) Paging() [][]string {
+	return nil
+}
+
+// ======================================
+
+// ConnectorsApplyPrinter ...
+type ConnectorsApplyPrinter struct {
+	Changes []ConnectorChange `json:"changes"`
+	DryRun  bool              `json:"dry_run"`
+}
+
+// JSON ...
+func (c *ConnectorsApplyPrinter) JSON() []byte {
+	return printer.MarshalObject(c, "json")
+}
+
+// YAML ...
+func (c *ConnectorsApplyPrinter) YAML() []byte {
+	return printer.MarshalObject(c, "yaml")
+}
+
+// Columns ...
+func (c *ConnectorsApplyPrinter) Columns() [][]string {
+	return [][]string{0: {
+		"ACTION",
+		"NAME",
+		"CLASS",
+		"TOPICS",
+	}}
+}
+
+// Data ...
+func (c *ConnectorsApplyPrinter) Data() [][]string {
+	if len(c.Changes) == 0 {
+		return [][]string{0: {"---", "---", "---", "---"}}
+	}
+
+	var data [][]string
+	for i := range c.Changes {
+		data = append(data, []string{
+			c.Changes[i].Action,
+			c.Changes[i].Name,
+			c.Changes[i].Class,
+			c.Changes[i].Topics,
+		})
+	}
+
+	return data
+}
+
+// Paging ...
+func (c *ConnectorsApplyPrinter) Paging() [][]string {
+	if !c.DryRun {
+		return nil
+	}
+
+	return [][]string{
+		0: {"======================================"},
+		1: {"DRY RUN: no changes have been made"},
+	}
+}
+
+// ======================================
+
+// KafkaApplyPrinter ...
+type KafkaApplyPrinter struct {
+	Changes []KafkaChange `json:"changes"`
+	DryRun  bool          `json:"dry_run"`
+}
+
+// JSON ...
+func (k *KafkaApplyPrinter) JSON() []byte {
+	return printer.MarshalObject(k, "json")
+}
+
+// YAML ...
+func (k *KafkaApplyPrinter) YAML() []byte {
+	return printer.MarshalObject(k, "yaml")
+}
+
+// Columns ...
+func (k *KafkaApplyPrinter) Columns() [][]string {
+	return [][]string{0: {
+		"ACTION",
+		"TYPE",
+		"NAME",
+		"DETAILS",
+	}}
+}
+
+// Data ...
+func (k *KafkaApplyPrinter) Data() [][]string {
+	if len(k.Changes) == 0 {
+		return [][]string{0: {"---", "---", "---", "---"}}
+	}
+
+	var data [][]string
+	for i := range k.Changes {
+		data = append(data, []string{
+			k.Changes[i].Action,
+			k.Changes[i].Type,
+			k.Changes[i].Name,
+			k.Changes[i].Details,
+		})
+	}
+
+	return data
+}
+
+// Paging ...
+func (k *KafkaApplyPrinter) Paging() [][]string {
+	if !k.DryRun {
+		return nil
+	}
+
+	return [][]string{
+		0: {"======================================"},
+		1: {"DRY RUN: no changes have been made"},
+	}
+}
+
+// ======================================
+
+// HealthPrinter ...
+type HealthPrinter struct {
+	Health *DatabaseHealth `json:"health"`
+}
+
+// JSON ...
+func (h *HealthPrinter) JSON() []byte {
+	return printer.MarshalObject(h, "json")
+}
+
+// YAML ...
+func (h *HealthPrinter) YAML() []byte {
+	return printer.MarshalObject(h, "yaml")
+}
+
+// Columns ...
+func (h *HealthPrinter) Columns() [][]string {
+	return nil
+}
+
+// Data ...
+func (h *HealthPrinter) Data() [][]string {
+	data := [][]string{
+		{"ID", h.Health.ID},
+		{"LABEL", h.Health.Label},
+		{"ENGINE", h.Health.Engine},
+		{"STATUS", h.Health.Status},
+		{"HEALTH", h.Health.Level},
+		{" "},
+		{"CHECKS"},
+		{"NAME", "LEVEL", "DETAIL"},
+	}
+
+	for i := range h.Health.Checks {
+		data = append(data, []string{h.Health.Checks[i].Name, h.Health.Checks[i].Level, h.Health.Checks[i].Detail})
+	}
+
+	if len(h.Health.Maintenance) > 0 {
+		data = append(data, []string{" "}, []string{"PENDING MAINTENANCE UPDATES"})
+		for i := range h.Health.Maintenance {
+			data = append(data, []string{h.Health.Maintenance[i]})
+		}
+	}
+
+	if len(h.Health.Alerts) > 0 {
+		data = append(data, []string{" "}, []string{"ALERTS"}, []string{"TIMESTAMP", "MESSAGE TYPE", "DESCRIPTION"})
+		for i := range h.Health.Alerts {
+			data = append(data, []string{
+				h.Health.Alerts[i].Timestamp,
+				h.Health.Alerts[i].MessageType,
+				h.Health.Alerts[i].Description,
+			})
+		}
+	}
+
+	if len(h.Health.Replicas) > 0 {
+		data = append(data, []string{" "}, []string{"READ REPLICAS"}, []string{"ID", "LABEL", "REGION", "STATUS"})
+		for i := range h.Health.Replicas {
+			data = append(data, []string{
+				h.Health.Replicas[i].ID,
+				h.Health.Replicas[i].Label,
+				h.Health.Replicas[i].Region,
+				h.Health.Replicas[i].Status,
+			})
+		}
+	}
+
+	if h.Health.Connections != nil {
+		data = append(data,
+			[]string{" "},
+			[]string{"CONNECTIONS"},
+			[]string{"USED", strconv.Itoa(h.Health.Connections.Used)},
+			[]string{"AVAILABLE", strconv.Itoa(h.Health.Connections.Available)},
+			[]string{"MAX", strconv.Itoa(h.Health.Connections.Max)},
+		)
+
+		if len(h.Health.Pools) > 0 {
+			data = append(data, []string{" "}, []string{"CONNECTION POOLS"}, []string{"NAME", "DATABASE", "MODE", "SIZE"})
+			for i := range h.Health.Pools {
+				data = append(data, []string{
+					h.Health.Pools[i].Name,
+					h.Health.Pools[i].Database,
+					h.Health.Pools[i].Mode,
+					strconv.Itoa(h.Health.Pools[i].Size),
+				})
+			}
+		}
+	}
+
+	return data
+}
+
+// Paging ...
+func (h *HealthPrinter) Paging() [][]string {
+	return nil
+}
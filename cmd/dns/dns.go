@@ -150,6 +150,16 @@ func NewCmdDNS(base *cli.Base) *cobra.Command { //nolint:funlen,gocyclo
 			return nil
 		},
 		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := utils.ConfirmDelete(cmd, func() (*utils.Resource, error) {
+				domain, err := o.domainGet()
+				if err != nil {
+					return nil, err
+				}
+				return &utils.Resource{Kind: "dns domain", ID: domain.Domain, Label: domain.Domain}, nil
+			}); err != nil {
+				return err
+			}
+
 			if err := o.domainDelete(); err != nil {
 				return fmt.Errorf("error delete dns domain : %v", err)
 			}
@@ -160,6 +170,8 @@ func NewCmdDNS(base *cli.Base) *cobra.Command { //nolint:funlen,gocyclo
 		},
 	}
 
+	utils.AddConfirmFlags(domainDelete)
+
 	// Domain DNSSEC Update
 	domainDNSSEC := &cobra.Command{
 		Use:   "dnssec <Domain Name>",
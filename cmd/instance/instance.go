@@ -10,6 +10,8 @@ import (
 
 	"github.com/spf13/cobra"
 	"github.com/vultr/govultr/v3"
+	"github.com/vultr/vultr-cli/v3/cmd/bulk"
+	"github.com/vultr/vultr-cli/v3/cmd/cost"
 	"github.com/vultr/vultr-cli/v3/cmd/ip"
 	"github.com/vultr/vultr-cli/v3/cmd/printer"
 	"github.com/vultr/vultr-cli/v3/cmd/userdata"
@@ -50,6 +52,10 @@ var (
 	vultr-cli instance create --region="ewr" --plan="vc2-2c-4gb" --os=1743 \
 		--ssh-keys="a14b6539-5583-41e8-a035-c07a76897f2b,be624232-56c7-4d5c-bf87-9bdaae7a1fbd"
 
+	# Full example with templated, multi-part user data
+	vultr-cli instance create --region="ewr" --plan="vc2-2c-4gb" --os=1743 \
+		--userdata-file="cloud-config.yaml" --userdata-file="setup.sh" --userdata-var="env=production"
+
 	# Block devices options
 	The --block-devices option allows you to pass in options for any number of
 	block storage devices when creating an instance with a VX1 plan. The options
@@ -75,9 +81,39 @@ var (
 	--block-devices="block-id:local/block-id:BLOCK_DEVICE_ID,bootable:true"
 	`
 	deleteLong    = ``
-	deleteExample = ``
-	tagsLong      = `Modify the tags of the specified instance`
-	tagsExample   = `
+	deleteExample = `
+	# Full example
+	vultr-cli instance delete 2126b7d9-5e2a-491e-8840-838aa6b5f294
+
+	# Delete every instance tagged ci-runner in ewr, without the confirmation prompt
+	vultr-cli instance delete --selector="tag=ci-runner,region=ewr" --yes
+
+	# Delete every instance created more than 30 days ago tagged ci-runner
+	vultr-cli instance delete --selector="tag=ci-runner" --older-than="30d"
+	`
+	startExample = `
+	# Full example
+	vultr-cli instance start 2126b7d9-5e2a-491e-8840-838aa6b5f294
+
+	# Start every stopped instance tagged ci-runner
+	vultr-cli instance start --selector="tag=ci-runner,power=stopped"
+	`
+	stopExample = `
+	# Full example
+	vultr-cli instance stop 2126b7d9-5e2a-491e-8840-838aa6b5f294
+
+	# Stop every instance tagged ci-runner, 2 at a time
+	vultr-cli instance stop --selector="tag=ci-runner" --concurrency=2
+	`
+	restartExample = `
+	# Full example
+	vultr-cli instance restart 2126b7d9-5e2a-491e-8840-838aa6b5f294
+
+	# Restart every instance with the web tag outside of ewr
+	vultr-cli instance restart --selector="tag=web,region!=ewr"
+	`
+	tagsLong    = `Modify the tags of the specified instance`
+	tagsExample = `
 	# Full example
 	vultr-cli instance tags <instanceID> --tags="example-tag-1,example-tag-2"
 
@@ -93,11 +129,25 @@ var (
 	Or with a file instead: 
 
 	vultr-cli instance user-data set <instanceID> --file="/home/me/user_data.txt"
+
+	Multiple files are assembled into a multi-part MIME user data:
+
+	vultr-cli instance user-data set <instanceID> --file="cloud-config.yaml" --file="setup.sh"
+
+	Files and text are rendered as Go templates when vars are provided:
+
+	vultr-cli instance user-data set <instanceID> --file="cloud-config.yaml" --var="hostname=web-1"
+
+	Cloud-config user data is validated for YAML syntax and size unless
+	--skip-validation is set.  Unknown top-level keys are reported as warnings.
 	`
 	userDataGetLong    = `Retrieve the user data from an instance`
 	userDataGetExample = `
 	# Full example
 	vultr-cli instance user-data get <instanceID>"
+
+	# Print only the decoded contents
+	vultr-cli instance user-data get <instanceID> --decode > user_data.txt
 	`
 	vpcAttachLong    = `Attaches an existing VPC to the specified instance`
 	vpcAttachExample = `
@@ -122,6 +172,9 @@ var (
 	`
 )
 
+// selectorKeys are the keys supported by the instance bulk --selector flag
+var selectorKeys = []string{"tag", "region", "plan", "label", "hostname", "os", "status", "power"}
+
 // NewCmdInstance ...
 func NewCmdInstance(base *cli.Base) *cobra.Command { //nolint:funlen,gocyclo
 	o := &options{Base: base}
@@ -286,11 +339,21 @@ func NewCmdInstance(base *cli.Base) *cobra.Command { //nolint:funlen,gocyclo
 				return fmt.Errorf("error parsing flag 'userdata' for instance create : %v", errUs)
 			}
 
-			userDataFile, errUd := cmd.Flags().GetString("userdata-file")
+			userDataFiles, errUd := cmd.Flags().GetStringArray("userdata-file")
 			if errUd != nil {
 				return fmt.Errorf("error parsing flag 'userdata-file' for instance create : %v", errUd)
 			}
 
+			userDataVars, errUv := cmd.Flags().GetStringArray("userdata-var")
+			if errUv != nil {
+				return fmt.Errorf("error parsing flag 'userdata-var' for instance create : %v", errUv)
+			}
+
+			userDataSkip, errUk := cmd.Flags().GetBool("userdata-skip-validation")
+			if errUk != nil {
+				return fmt.Errorf("error parsing flag 'userdata-skip-validation' for instance create : %v", errUk)
+			}
+
 			notify, errNo := cmd.Flags().GetBool("notify")
 			if errNo != nil {
 				return fmt.Errorf("error parsing flag 'notify' for instance create : %v", errNo)
@@ -331,6 +394,11 @@ func NewCmdInstance(base *cli.Base) *cobra.Command { //nolint:funlen,gocyclo
 				return fmt.Errorf("error parsing flag 'vpc-only' for instance create : %v", errVO)
 			}
 
+			estimate, errEs := cmd.Flags().GetBool("estimate")
+			if errEs != nil {
+				return fmt.Errorf("error parsing flag 'estimate' for instance create : %v", errEs)
+			}
+
 			o.CreateReq = &govultr.InstanceCreateReq{
 				Plan:            plan,
 				Region:          region,
@@ -343,7 +411,6 @@ func NewCmdInstance(base *cli.Base) *cobra.Command { //nolint:funlen,gocyclo
 				ScriptID:        script,
 				Label:           label,
 				SSHKeys:         ssh,
-				UserData:        userData,
 				ReservedIPv4:    ipv4,
 				Hostname:        host,
 				Tags:            tags,
@@ -361,15 +428,14 @@ func NewCmdInstance(base *cli.Base) *cobra.Command { //nolint:funlen,gocyclo
 				o.CreateReq.Backups = "enabled"
 			}
 
-			if userData != "" {
-				ud := userdata.NewUserDataFromString(userData)
-				o.CreateReq.UserData = ud.Base64Encode()
+			ud, errUD := userdata.Build(userData, userDataFiles, userDataVars, !userDataSkip)
+			if errUD != nil {
+				return fmt.Errorf("error with user data for instance create : %v", errUD)
 			}
 
-			if userDataFile != "" {
-				ud, err := userdata.NewUserDataFromFile(userDataFile)
-				if err != nil {
-					return fmt.Errorf("error reading user data from file : %v", err)
+			if ud != nil {
+				for i := range ud.Warnings {
+					fmt.Fprintf(os.Stderr, "warning: %s\n", ud.Warnings[i])
 				}
 				o.CreateReq.UserData = ud.Base64Encode()
 			}
@@ -383,6 +449,10 @@ func NewCmdInstance(base *cli.Base) *cobra.Command { //nolint:funlen,gocyclo
 				o.CreateReq.BlockDevices = bds
 			}
 
+			if estimate {
+				return o.estimate()
+			}
+
 			instance, err := o.create()
 			if err != nil {
 				return fmt.Errorf("error creating instance : %v", err)
@@ -440,12 +510,23 @@ func NewCmdInstance(base *cli.Base) *cobra.Command { //nolint:funlen,gocyclo
 		"",
 		"plain text userdata you want to give to this instance",
 	)
-	create.Flags().String(
+	create.Flags().StringArray(
 		"userdata-file",
-		"",
-		"file path to read in for the userdata you want to give to this instance",
+		[]string{},
+		"file path to read in for the userdata you want to give to this instance. "+
+			"Repeat to assemble multiple files into a multi-part MIME user data",
 	)
 	create.MarkFlagsMutuallyExclusive("userdata", "userdata-file")
+	create.Flags().StringArray(
+		"userdata-var",
+		[]string{},
+		"key=value pair to render the userdata as a Go template, accessed as {{ .key }}. Can be repeated",
+	)
+	create.Flags().Bool(
+		"userdata-skip-validation",
+		false,
+		"skip the validation of the userdata size and cloud-config contents",
+	)
 
 	create.Flags().BoolP("notify", "n", false, "notify when instance has been created | true or false")
 	create.Flags().BoolP("ddos", "d", false, "enable ddos protection | true or false")
@@ -459,6 +540,7 @@ func NewCmdInstance(base *cli.Base) *cobra.Command { //nolint:funlen,gocyclo
 		[]string{},
 		`a comma-separated, key-value pair list of block devices. At least one block is required for VX1 plans.`,
 	)
+	cost.AddFlag(create)
 
 	// Update
 	// update := &cobra.Command{}
@@ -470,13 +552,40 @@ func NewCmdInstance(base *cli.Base) *cobra.Command { //nolint:funlen,gocyclo
 		Aliases: []string{"destroy"},
 		Long:    deleteLong,
 		Example: deleteExample,
-		Args: func(cmd *cobra.Command, args []string) error {
-			if len(args) < 1 {
-				return errors.New("please provide an instance ID")
-			}
-			return nil
-		},
+		Args:    bulk.Args("please provide an instance ID"),
 		RunE: func(cmd *cobra.Command, args []string) error {
+			bo, errBu := bulk.GetFlags(cmd, selectorKeys)
+			if errBu != nil {
+				return fmt.Errorf("error parsing bulk flags for instance delete : %v", errBu)
+			}
+
+			if bo.Enabled() {
+				items, err := o.selectInstances(bo)
+				if err != nil {
+					return fmt.Errorf("error selecting instances : %v", err)
+				}
+
+				return bo.Execute(o.Base.Printer, "instance delete", items, func(id string) error {
+					return o.Base.Client.Instance.Delete(o.Base.Context, id)
+				})
+			}
+
+			if err := utils.ConfirmDelete(cmd, func() (*utils.Resource, error) {
+				instance, err := o.get()
+				if err != nil {
+					return nil, err
+				}
+				return &utils.Resource{
+					Kind:   "instance",
+					ID:     instance.ID,
+					Label:  instance.Label,
+					Region: instance.Region,
+					Tags:   instance.Tags,
+				}, nil
+			}); err != nil {
+				return err
+			}
+
 			if err := o.del(); err != nil {
 				return fmt.Errorf("error deleting instance : %v", err)
 			}
@@ -487,6 +596,8 @@ func NewCmdInstance(base *cli.Base) *cobra.Command { //nolint:funlen,gocyclo
 		},
 	}
 
+	bulk.AddDeleteFlags(del, selectorKeys)
+
 	// Label
 	label := &cobra.Command{
 		Use:   "label <Instance ID>",
@@ -586,11 +697,27 @@ func NewCmdInstance(base *cli.Base) *cobra.Command { //nolint:funlen,gocyclo
 			return nil
 		},
 		RunE: func(cmd *cobra.Command, args []string) error {
+			decode, errDe := cmd.Flags().GetBool("decode")
+			if errDe != nil {
+				return fmt.Errorf("error parsing flag 'decode' for instance userdata get : %v", errDe)
+			}
+
 			ud, err := o.userData()
 			if err != nil {
 				return fmt.Errorf("error getting instance user data : %v", err)
 			}
 
+			if decode {
+				dec, errDec := userdata.NewUserDataFromBase64(ud.Data)
+				if errDec != nil {
+					return errDec
+				}
+
+				fmt.Fprintf(cmd.OutOrStdout(), "%s", dec.Data)
+
+				return nil
+			}
+
 			data := &userdata.UserDataPrinter{UserData: *ud}
 			o.Base.Printer.Display(data, nil)
 
@@ -598,6 +725,8 @@ func NewCmdInstance(base *cli.Base) *cobra.Command { //nolint:funlen,gocyclo
 		},
 	}
 
+	userDataGet.Flags().Bool("decode", false, "print only the decoded user data contents")
+
 	// User Data Set
 	userDataSet := &cobra.Command{
 		Use:     "set <Instance ID>",
@@ -616,24 +745,36 @@ func NewCmdInstance(base *cli.Base) *cobra.Command { //nolint:funlen,gocyclo
 				return fmt.Errorf("error parsing flag 'text' for instance userdata set : %v", err)
 			}
 
-			file, err := cmd.Flags().GetString("file")
+			files, err := cmd.Flags().GetStringArray("file")
 			if err != nil {
 				return fmt.Errorf("error parsing flag 'file' for instance userdata set : %v", err)
 			}
 
-			o.UpdateReq = &govultr.InstanceUpdateReq{}
+			vars, err := cmd.Flags().GetStringArray("var")
+			if err != nil {
+				return fmt.Errorf("error parsing flag 'var' for instance userdata set : %v", err)
+			}
 
-			if text != "" {
-				ud := userdata.NewUserDataFromString(text)
-				o.UpdateReq.UserData = ud.Base64Encode()
+			skip, err := cmd.Flags().GetBool("skip-validation")
+			if err != nil {
+				return fmt.Errorf("error parsing flag 'skip-validation' for instance userdata set : %v", err)
 			}
 
-			if file != "" {
-				ud, err := userdata.NewUserDataFromFile(file)
-				if err != nil {
-					return fmt.Errorf("error reading from file for instance userdata set : %v", err)
-				}
-				o.UpdateReq.UserData = ud.Base64Encode()
+			ud, err := userdata.Build(text, files, vars, !skip)
+			if err != nil {
+				return fmt.Errorf("error with user data for instance userdata set : %v", err)
+			}
+
+			if ud == nil {
+				return errors.New("no user data given for instance userdata set")
+			}
+
+			for i := range ud.Warnings {
+				fmt.Fprintf(os.Stderr, "warning: %s\n", ud.Warnings[i])
+			}
+
+			o.UpdateReq = &govultr.InstanceUpdateReq{
+				UserData: ud.Base64Encode(),
 			}
 
 			if _, err := o.update(); err != nil {
@@ -647,7 +788,22 @@ func NewCmdInstance(base *cli.Base) *cobra.Command { //nolint:funlen,gocyclo
 	}
 
 	userDataSet.Flags().StringP("text", "t", "", "plain text to insert into instance user data")
-	userDataSet.Flags().StringP("file", "f", "", "file path from which to read the instance user data")
+	userDataSet.Flags().StringArrayP(
+		"file",
+		"f",
+		[]string{},
+		"file path from which to read the instance user data. Repeat to assemble a multi-part MIME user data",
+	)
+	userDataSet.Flags().StringArray(
+		"var",
+		[]string{},
+		"key=value pair to render the user data as a Go template, accessed as {{ .key }}. Can be repeated",
+	)
+	userDataSet.Flags().Bool(
+		"skip-validation",
+		false,
+		"skip the validation of the user data size and cloud-config contents",
+	)
 	userDataSet.MarkFlagsMutuallyExclusive("text", "file")
 	userDataSet.MarkFlagsOneRequired("text", "file")
 
@@ -658,16 +814,28 @@ func NewCmdInstance(base *cli.Base) *cobra.Command { //nolint:funlen,gocyclo
 
 	// Start
 	start := &cobra.Command{
-		Use:   "start <Instance ID>",
-		Short: "Start an instance",
-		Long:  ``,
-		Args: func(cmd *cobra.Command, args []string) error {
-			if len(args) < 1 {
-				return errors.New("please provide an instance ID")
-			}
-			return nil
-		},
+		Use:     "start <Instance ID>",
+		Short:   "Start an instance",
+		Long:    ``,
+		Example: startExample,
+		Args:    bulk.Args("please provide an instance ID"),
 		RunE: func(cmd *cobra.Command, args []string) error {
+			bo, errBu := bulk.GetFlags(cmd, selectorKeys)
+			if errBu != nil {
+				return fmt.Errorf("error parsing bulk flags for instance start : %v", errBu)
+			}
+
+			if bo.Enabled() {
+				items, err := o.selectInstances(bo)
+				if err != nil {
+					return fmt.Errorf("error selecting instances : %v", err)
+				}
+
+				return bo.Execute(o.Base.Printer, "instance start", items, func(id string) error {
+					return o.Base.Client.Instance.Start(o.Base.Context, id)
+				})
+			}
+
 			if err := o.start(); err != nil {
 				return fmt.Errorf("error starting instance : %v", err)
 			}
@@ -680,16 +848,28 @@ func NewCmdInstance(base *cli.Base) *cobra.Command { //nolint:funlen,gocyclo
 
 	// Stop
 	stop := &cobra.Command{
-		Use:   "stop <Instance ID>",
-		Short: "Stop an instance",
-		Long:  ``,
-		Args: func(cmd *cobra.Command, args []string) error {
-			if len(args) < 1 {
-				return errors.New("please provide an instance ID")
-			}
-			return nil
-		},
+		Use:     "stop <Instance ID>",
+		Short:   "Stop an instance",
+		Long:    ``,
+		Example: stopExample,
+		Args:    bulk.Args("please provide an instance ID"),
 		RunE: func(cmd *cobra.Command, args []string) error {
+			bo, errBu := bulk.GetFlags(cmd, selectorKeys)
+			if errBu != nil {
+				return fmt.Errorf("error parsing bulk flags for instance stop : %v", errBu)
+			}
+
+			if bo.Enabled() {
+				items, err := o.selectInstances(bo)
+				if err != nil {
+					return fmt.Errorf("error selecting instances : %v", err)
+				}
+
+				return bo.Execute(o.Base.Printer, "instance stop", items, func(id string) error {
+					return o.Base.Client.Instance.Halt(o.Base.Context, id)
+				})
+			}
+
 			if err := o.stop(); err != nil {
 				return fmt.Errorf("error stopping instance : %v", err)
 			}
@@ -702,16 +882,28 @@ func NewCmdInstance(base *cli.Base) *cobra.Command { //nolint:funlen,gocyclo
 
 	// Restart
 	restart := &cobra.Command{
-		Use:   "restart <Instance ID>",
-		Short: "Restart an instance",
-		Long:  ``,
-		Args: func(cmd *cobra.Command, args []string) error {
-			if len(args) < 1 {
-				return errors.New("please provide an instance ID")
-			}
-			return nil
-		},
+		Use:     "restart <Instance ID>",
+		Short:   "Restart an instance",
+		Long:    ``,
+		Example: restartExample,
+		Args:    bulk.Args("please provide an instance ID"),
 		RunE: func(cmd *cobra.Command, args []string) error {
+			bo, errBu := bulk.GetFlags(cmd, selectorKeys)
+			if errBu != nil {
+				return fmt.Errorf("error parsing bulk flags for instance restart : %v", errBu)
+			}
+
+			if bo.Enabled() {
+				items, err := o.selectInstances(bo)
+				if err != nil {
+					return fmt.Errorf("error selecting instances : %v", err)
+				}
+
+				return bo.Execute(o.Base.Printer, "instance restart", items, func(id string) error {
+					return o.Base.Client.Instance.Reboot(o.Base.Context, id)
+				})
+			}
+
 			if err := o.restart(); err != nil {
 				return fmt.Errorf("error restarting instance : %v", err)
 			}
@@ -722,6 +914,10 @@ func NewCmdInstance(base *cli.Base) *cobra.Command { //nolint:funlen,gocyclo
 		},
 	}
 
+	bulk.AddFlags(start, selectorKeys, false)
+	bulk.AddFlags(stop, selectorKeys, false)
+	bulk.AddFlags(restart, selectorKeys, false)
+
 	// ISO
 	iso := &cobra.Command{
 		Use:   "iso",
@@ -1853,11 +2049,72 @@ func (o *options) create() (*govultr.Instance, error) {
 	return inst, err
 }
 
+// estimate displays the estimated cost of the instance in the create request
+func (o *options) estimate() error {
+	items, err := cost.NewEstimator(o.Base).Instance(
+		o.CreateReq.Plan,
+		o.CreateReq.Region,
+		1,
+		o.CreateReq.Backups == "enabled",
+	)
+	if err != nil {
+		return fmt.Errorf("error estimating instance cost : %v", err)
+	}
+
+	o.Base.Printer.Display(&cost.EstimatePrinter{Estimate: cost.NewEstimate(items...)}, nil)
+
+	return nil
+}
+
 func (o *options) update() (*govultr.Instance, error) {
 	inst, _, err := o.Base.Client.Instance.Update(o.Base.Context, o.Base.Args[0], o.UpdateReq)
 	return inst, err
 }
 
+func (o *options) listAll() ([]govultr.Instance, error) {
+	return utils.ListAll(func(opts *govultr.ListOptions) ([]govultr.Instance, *govultr.Meta, error) {
+		instances, meta, _, err := o.Base.Client.Instance.List(o.Base.Context, opts)
+		return instances, meta, err
+	})
+}
+
+// selectInstances returns the instances matching the bulk selector and age
+func (o *options) selectInstances(bo *bulk.Options) ([]bulk.Item, error) {
+	instances, err := o.listAll()
+	if err != nil {
+		return nil, err
+	}
+
+	var items []bulk.Item
+	for i := range instances {
+		if bo.Selector != nil && !bo.Selector.Matches(map[string][]string{
+			"tag":      instances[i].Tags,
+			"region":   {instances[i].Region},
+			"plan":     {instances[i].Plan},
+			"label":    {instances[i].Label},
+			"hostname": {instances[i].Hostname},
+			"os":       {instances[i].Os},
+			"status":   {instances[i].Status},
+			"power":    {instances[i].PowerStatus},
+		}) {
+			continue
+		}
+
+		if bo.OlderThan > 0 && !bulk.OlderThan(instances[i].DateCreated, bo.OlderThan) {
+			continue
+		}
+
+		items = append(items, bulk.Item{
+			ID:     instances[i].ID,
+			Name:   instances[i].Label,
+			Region: instances[i].Region,
+			Tags:   instances[i].Tags,
+		})
+	}
+
+	return items, nil
+}
+
 func (o *options) del() error {
 	return o.Base.Client.Instance.Delete(o.Base.Context, o.Base.Args[0])
 }
@@ -0,0 +1,303 @@
+// Package inventory provides the commands to generate SSH config and Ansible
+// inventories from the account resources
+package inventory
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/vultr/govultr/v3"
+	"github.com/vultr/vultr-cli/v3/cmd/utils"
+	"github.com/vultr/vultr-cli/v3/pkg/cli"
+)
+
+const (
+	formatSSHConfig   string = "ssh-config"
+	formatAnsibleINI  string = "ansible-ini"
+	formatAnsibleYAML string = "ansible-yaml"
+	formatJSON        string = "json"
+
+	hostTypeInstance  string = "instance"
+	hostTypeBareMetal string = "bare-metal"
+)
+
+var (
+	long = `Generate SSH config entries or an Ansible inventory from the instances
+and bare metal servers on your account.
+
+Hosts are named by their label (or ID when no label is set) and are grouped by
+type, region, plan, operating system and tag.  The command can also be used as
+an Ansible dynamic inventory script through the --list and --host flags.`
+	example = `
+	# Full example
+	vultr-cli inventory --format="ssh-config"
+
+	# Append SSH config entries using the internal IPs and a specific user
+	vultr-cli inventory --format="ssh-config" --user="deploy" --internal-ip >> ~/.ssh/config
+
+	# Write a static Ansible inventory
+	vultr-cli inventory --format="ansible-ini" > hosts.ini
+	vultr-cli inventory --format="ansible-yaml" > hosts.yaml
+
+	# Ansible dynamic inventory, wrapped in an executable script
+	#!/bin/sh
+	exec vultr-cli inventory "$@"
+
+	vultr-cli inventory --list
+	vultr-cli inventory --host="web-1"
+
+	# Shortened with alias commands
+	vultr-cli inv -f ansible-ini
+	`
+)
+
+// NewCmdInventory provides the CLI command for inventory generation
+func NewCmdInventory(base *cli.Base) *cobra.Command { //nolint:funlen,gocyclo
+	o := &options{Base: base}
+
+	cmd := &cobra.Command{
+		Use:     "inventory",
+		Short:   "Generate SSH config and Ansible inventories",
+		Aliases: []string{"inv"},
+		Long:    long,
+		Example: example,
+		PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
+			utils.SetOptions(o.Base, cmd, args)
+			if !o.Base.HasAuth() {
+				return errors.New(utils.APIKeyError)
+			}
+			return nil
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			format, errFo := cmd.Flags().GetString("format")
+			if errFo != nil {
+				return fmt.Errorf("error parsing flag 'format' for inventory : %v", errFo)
+			}
+
+			user, errUs := cmd.Flags().GetString("user")
+			if errUs != nil {
+				return fmt.Errorf("error parsing flag 'user' for inventory : %v", errUs)
+			}
+
+			identity, errId := cmd.Flags().GetString("identity-file")
+			if errId != nil {
+				return fmt.Errorf("error parsing flag 'identity-file' for inventory : %v", errId)
+			}
+
+			internal, errIn := cmd.Flags().GetBool("internal-ip")
+			if errIn != nil {
+				return fmt.Errorf("error parsing flag 'internal-ip' for inventory : %v", errIn)
+			}
+
+			list, errLi := cmd.Flags().GetBool("list")
+			if errLi != nil {
+				return fmt.Errorf("error parsing flag 'list' for inventory : %v", errLi)
+			}
+
+			host, errHo := cmd.Flags().GetString("host")
+			if errHo != nil {
+				return fmt.Errorf("error parsing flag 'host' for inventory : %v", errHo)
+			}
+
+			if list || host != "" {
+				format = formatJSON
+			}
+
+			switch format {
+			case formatSSHConfig, formatAnsibleINI, formatAnsibleYAML, formatJSON:
+			default:
+				return fmt.Errorf(
+					"invalid inventory format %q, must be one of: %s",
+					format,
+					strings.Join([]string{formatSSHConfig, formatAnsibleINI, formatAnsibleYAML, formatJSON}, ", "),
+				)
+			}
+
+			o.User = user
+			o.IdentityFile = identity
+			o.InternalIP = internal
+
+			hosts, err := o.hosts()
+			if err != nil {
+				return fmt.Errorf("error building inventory : %v", err)
+			}
+
+			inv := &Inventory{Hosts: hosts, User: o.User, IdentityFile: o.IdentityFile}
+
+			var out []byte
+			switch {
+			case host != "":
+				vars, errVa := inv.HostVars(host)
+				if errVa != nil {
+					return errVa
+				}
+				out = vars
+			case format == formatSSHConfig:
+				out = inv.SSHConfig()
+			case format == formatAnsibleINI:
+				out = inv.AnsibleINI()
+			case format == formatAnsibleYAML:
+				out = inv.AnsibleYAML()
+			default:
+				out = inv.AnsibleJSON()
+			}
+
+			fmt.Fprintf(cmd.OutOrStdout(), "%s", out)
+
+			return nil
+		},
+	}
+
+	cmd.Flags().StringP(
+		"format",
+		"f",
+		formatSSHConfig,
+		"(optional) Inventory format. Possible values: 'ssh-config', 'ansible-ini', 'ansible-yaml', 'json'.",
+	)
+	cmd.Flags().StringP("user", "u", "root", "(optional) The SSH user set on each host.")
+	cmd.Flags().StringP("identity-file", "i", "", "(optional) The SSH identity file set on each host.")
+	cmd.Flags().Bool(
+		"internal-ip",
+		false,
+		"(optional) Connect to hosts via their internal (VPC) IP rather than the main IP.",
+	)
+	cmd.Flags().Bool("list", false, "(optional) Print the full Ansible dynamic inventory as JSON.")
+	cmd.Flags().String("host", "", "(optional) Print the Ansible host variables of the specified host as JSON.")
+	cmd.MarkFlagsMutuallyExclusive("list", "host")
+	cmd.MarkFlagsMutuallyExclusive("list", "format")
+	cmd.MarkFlagsMutuallyExclusive("host", "format")
+
+	return cmd
+}
+
+type options struct {
+	Base         *cli.Base
+	User         string
+	IdentityFile string
+	InternalIP   bool
+}
+
+// hosts retrieves all instances and bare metal servers and converts them into
+// inventory hosts with unique names
+func (o *options) hosts() ([]Host, error) {
+	instances, err := o.instances()
+	if err != nil {
+		return nil, fmt.Errorf("error retrieving instances : %v", err)
+	}
+
+	bms, err := o.bareMetals()
+	if err != nil {
+		return nil, fmt.Errorf("error retrieving bare metal servers : %v", err)
+	}
+
+	var hosts []Host
+	for i := range instances {
+		hosts = append(hosts, Host{
+			ID:         instances[i].ID,
+			Type:       hostTypeInstance,
+			Label:      instances[i].Label,
+			Region:     instances[i].Region,
+			Plan:       instances[i].Plan,
+			OS:         instances[i].Os,
+			MainIP:     instances[i].MainIP,
+			V6MainIP:   instances[i].V6MainIP,
+			InternalIP: instances[i].InternalIP,
+			Tags:       instances[i].Tags,
+		})
+	}
+
+	for i := range bms {
+		hosts = append(hosts, Host{
+			ID:       bms[i].ID,
+			Type:     hostTypeBareMetal,
+			Label:    bms[i].Label,
+			Region:   bms[i].Region,
+			Plan:     bms[i].Plan,
+			OS:       bms[i].Os,
+			MainIP:   bms[i].MainIP,
+			V6MainIP: bms[i].V6MainIP,
+			Tags:     bms[i].Tags,
+		})
+	}
+
+	for i := range hosts {
+		hosts[i].Address = hosts[i].MainIP
+		if o.InternalIP && hosts[i].InternalIP != "" {
+			hosts[i].Address = hosts[i].InternalIP
+		}
+	}
+
+	nameHosts(hosts)
+
+	return hosts, nil
+}
+
+func (o *options) instances() ([]govultr.Instance, error) {
+	return utils.ListAll(func(opts *govultr.ListOptions) ([]govultr.Instance, *govultr.Meta, error) {
+		instances, meta, _, err := o.Base.Client.Instance.List(o.Base.Context, opts)
+		return instances, meta, err
+	})
+}
+
+func (o *options) bareMetals() ([]govultr.BareMetalServer, error) {
+	return utils.ListAll(func(opts *govultr.ListOptions) ([]govultr.BareMetalServer, *govultr.Meta, error) {
+		bms, meta, _, err := o.Base.Client.BareMetalServer.List(o.Base.Context, opts)
+		return bms, meta, err
+	})
+}
+
+var (
+	hostNameRegex  = regexp.MustCompile(`[^A-Za-z0-9._-]+`)
+	groupNameRegex = regexp.MustCompile(`[^A-Za-z0-9_]+`)
+)
+
+// nameHosts sets a unique name on each host, based on the label and falling
+// back to the ID.  Duplicate labels are suffixed with the start of the ID.
+func nameHosts(hosts []Host) {
+	sort.SliceStable(hosts, func(i, j int) bool {
+		if hosts[i].Label == hosts[j].Label {
+			return hosts[i].ID < hosts[j].ID
+		}
+		return hosts[i].Label < hosts[j].Label
+	})
+
+	counts := map[string]int{}
+	for i := range hosts {
+		counts[hostName(hosts[i].Label)]++
+	}
+
+	for i := range hosts {
+		name := hostName(hosts[i].Label)
+		switch {
+		case name == "":
+			name = hosts[i].ID
+		case counts[name] > 1:
+			name = fmt.Sprintf("%s-%s", name, shortID(hosts[i].ID))
+		}
+		hosts[i].Name = name
+	}
+}
+
+func hostName(label string) string {
+	return strings.Trim(hostNameRegex.ReplaceAllString(strings.TrimSpace(label), "-"), "-")
+}
+
+// groupName formats a group name to be valid in Ansible
+func groupName(prefix, value string) string {
+	value = strings.Trim(groupNameRegex.ReplaceAllString(strings.ToLower(value), "_"), "_")
+	if value == "" {
+		return ""
+	}
+	return fmt.Sprintf("%s_%s", prefix, value)
+}
+
+func shortID(id string) string {
+	if i := strings.Index(id, "-"); i > 0 {
+		return id[:i]
+	}
+	return id
+}
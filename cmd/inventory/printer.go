@@ -0,0 +1,252 @@
+package inventory
+
+import (
+	"bytes"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/vultr/vultr-cli/v3/cmd/printer"
+)
+
+// Host is a single inventory entry built from an instance or bare metal server
+type Host struct {
+	Name       string   `json:"name"`
+	ID         string   `json:"id"`
+	Type       string   `json:"type"`
+	Label      string   `json:"label"`
+	Region     string   `json:"region"`
+	Plan       string   `json:"plan"`
+	OS         string   `json:"os"`
+	Address    string   `json:"address"`
+	MainIP     string   `json:"main_ip"`
+	V6MainIP   string   `json:"v6_main_ip"`
+	InternalIP string   `json:"internal_ip"`
+	Tags       []string `json:"tags"`
+}
+
+// groups returns the Ansible groups the host belongs to
+func (h *Host) groups() []string {
+	groups := []string{
+		groupName("type", h.Type),
+		groupName("region", h.Region),
+		groupName("plan", h.Plan),
+		groupName("os", h.OS),
+	}
+
+	for i := range h.Tags {
+		groups = append(groups, groupName("tag", h.Tags[i]))
+	}
+
+	var valid []string
+	for i := range groups {
+		if groups[i] != "" {
+			valid = append(valid, groups[i])
+		}
+	}
+
+	return valid
+}
+
+// vars returns the Ansible host variables
+func (h *Host) vars(user, identity string) map[string]interface{} {
+	vars := map[string]interface{}{
+		"ansible_host":      h.Address,
+		"vultr_id":          h.ID,
+		"vultr_type":        h.Type,
+		"vultr_label":       h.Label,
+		"vultr_region":      h.Region,
+		"vultr_plan":        h.Plan,
+		"vultr_os":          h.OS,
+		"vultr_main_ip":     h.MainIP,
+		"vultr_v6_main_ip":  h.V6MainIP,
+		"vultr_internal_ip": h.InternalIP,
+		"vultr_tags":        h.Tags,
+	}
+
+	if h.Tags == nil {
+		vars["vultr_tags"] = []string{}
+	}
+
+	if user != "" {
+		vars["ansible_user"] = user
+	}
+
+	if identity != "" {
+		vars["ansible_ssh_private_key_file"] = identity
+	}
+
+	return vars
+}
+
+// ======================================
+
+// Inventory renders a set of hosts in the supported inventory formats
+type Inventory struct {
+	Hosts        []Host
+	User         string
+	IdentityFile string
+}
+
+// groups returns a mapping of group name to the sorted host names in it
+func (i *Inventory) groups() map[string][]string {
+	groups := map[string][]string{}
+	for j := range i.Hosts {
+		for _, g := range i.Hosts[j].groups() {
+			groups[g] = append(groups[g], i.Hosts[j].Name)
+		}
+	}
+
+	for g := range groups {
+		sort.Strings(groups[g])
+	}
+
+	return groups
+}
+
+func sortedKeys(m map[string][]string) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// SSHConfig renders the hosts as ssh_config(5) entries
+func (i *Inventory) SSHConfig() []byte {
+	var b bytes.Buffer
+	for j := range i.Hosts {
+		h := &i.Hosts[j]
+		if h.Address == "" {
+			continue
+		}
+
+		fmt.Fprintf(&b, "# %s %s (%s, %s)\n", h.Type, h.ID, h.Region, h.Plan)
+		fmt.Fprintf(&b, "Host %s\n", h.Name)
+		fmt.Fprintf(&b, "    HostName %s\n", h.Address)
+		if i.User != "" {
+			fmt.Fprintf(&b, "    User %s\n", i.User)
+		}
+		if i.IdentityFile != "" {
+			fmt.Fprintf(&b, "    IdentityFile %s\n", i.IdentityFile)
+		}
+		b.WriteString("\n")
+	}
+
+	return b.Bytes()
+}
+
+// AnsibleINI renders the hosts as a static Ansible INI inventory
+func (i *Inventory) AnsibleINI() []byte {
+	var b bytes.Buffer
+
+	b.WriteString("[all]\n")
+	for j := range i.Hosts {
+		vars := i.Hosts[j].vars(i.User, i.IdentityFile)
+		keys := make([]string, 0, len(vars))
+		for k := range vars {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+
+		fields := []string{i.Hosts[j].Name}
+		for _, k := range keys {
+			var v string
+			switch val := vars[k].(type) {
+			case []string:
+				v = strings.Join(val, ",")
+			default:
+				v = fmt.Sprintf("%v", val)
+			}
+
+			if v == "" {
+				continue
+			}
+			fields = append(fields, fmt.Sprintf("%s=%q", k, v))
+		}
+		b.WriteString(strings.Join(fields, " "))
+		b.WriteString("\n")
+	}
+
+	groups := i.groups()
+	for _, g := range sortedKeys(groups) {
+		fmt.Fprintf(&b, "\n[%s]\n", g)
+		for _, h := range groups[g] {
+			fmt.Fprintf(&b, "%s\n", h)
+		}
+	}
+
+	return b.Bytes()
+}
+
+// AnsibleYAML renders the hosts as a static Ansible YAML inventory
+func (i *Inventory) AnsibleYAML() []byte {
+	hosts := map[string]interface{}{}
+	for j := range i.Hosts {
+		hosts[i.Hosts[j].Name] = i.Hosts[j].vars(i.User, i.IdentityFile)
+	}
+
+	children := map[string]interface{}{}
+	groups := i.groups()
+	for g := range groups {
+		members := map[string]interface{}{}
+		for _, h := range groups[g] {
+			members[h] = nil
+		}
+		children[g] = map[string]interface{}{"hosts": members}
+	}
+
+	all := map[string]interface{}{"hosts": hosts}
+	if len(children) > 0 {
+		all["children"] = children
+	}
+
+	return printer.MarshalObject(map[string]interface{}{"all": all}, "yaml")
+}
+
+// AnsibleJSON renders the hosts in the Ansible dynamic inventory JSON format,
+// as expected from the output of a script called with --list
+func (i *Inventory) AnsibleJSON() []byte {
+	hostvars := map[string]interface{}{}
+	var names []string
+	for j := range i.Hosts {
+		hostvars[i.Hosts[j].Name] = i.Hosts[j].vars(i.User, i.IdentityFile)
+		names = append(names, i.Hosts[j].Name)
+	}
+	sort.Strings(names)
+
+	groups := i.groups()
+	inv := map[string]interface{}{
+		"_meta": map[string]interface{}{"hostvars": hostvars},
+		"all": map[string]interface{}{
+			"hosts":    nonNil(names),
+			"children": nonNil(sortedKeys(groups)),
+		},
+	}
+
+	for g, members := range groups {
+		inv[g] = map[string]interface{}{"hosts": members}
+	}
+
+	return append(printer.MarshalObject(inv, "json"), '\n')
+}
+
+// HostVars renders the variables of a single host in JSON, as expected from
+// the output of a script called with --host
+func (i *Inventory) HostVars(name string) ([]byte, error) {
+	for j := range i.Hosts {
+		if i.Hosts[j].Name == name {
+			return append(printer.MarshalObject(i.Hosts[j].vars(i.User, i.IdentityFile), "json"), '\n'), nil
+		}
+	}
+
+	return nil, fmt.Errorf("no host found with name %q", name)
+}
+
+func nonNil(s []string) []string {
+	if s == nil {
+		return []string{}
+	}
+	return s
+}
@@ -7,11 +7,14 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"slices"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/spf13/cobra"
 	"github.com/vultr/govultr/v3"
+	"github.com/vultr/vultr-cli/v3/cmd/cost"
 	"github.com/vultr/vultr-cli/v3/cmd/printer"
 	"github.com/vultr/vultr-cli/v3/cmd/utils"
 	"github.com/vultr/vultr-cli/v3/pkg/cli"
@@ -52,6 +55,37 @@ var (
 	Using node labels 
 	--node-pools="quantity:5,plan:vc2-2c-4gb,label:worker-pool,auto-scaler:true,min-nodes:5,max-nodes:10, \
 		node-labels:application=identity-service|worker-size=small"
+
+	# Spec file
+	The --file option reads the cluster settings from a YAML or JSON document
+	instead of the other flags.  The version and node pool plans are checked
+	against 'kubernetes versions' and the plans available in the region before
+	the cluster is created.
+
+	vultr-cli kubernetes create --file cluster.yaml
+
+	label: my-cluster
+	region: ewr
+	version: v1.29.2+1
+	ha_control_planes: true
+	enable_firewall: true
+	vpc: e951822b-10b2-4c5e-b333-bf38033e7175
+	node_pools:
+	  - label: main-node-pool
+	    plan: vc2-4c-8gb
+	    quantity: 3
+	  - label: worker-pool
+	    plan: vc2-2c-4gb
+	    quantity: 5
+	    auto_scaler: true
+	    min_nodes: 5
+	    max_nodes: 10
+	    labels:
+	      application: identity-service
+	    taints:
+	      - key: dedicated
+	        value: workers
+	        effect: NoSchedule
 	`
 
 	getLong    = `Get a single kubernetes cluster from your account`
@@ -204,6 +238,43 @@ var (
 	vultr-cli k n d ffd31f18-5f77-454c-9065-212f942c3c35 abd31f18-3f77-454c-9064-212f942c3c34'
 	`
 
+	rollNPLong = `Recycle every node of a node pool, a batch at a time, for example to apply a
+plan change.
+
+The nodes in a batch, of at most --max-unavailable nodes, are recycled together.
+The next batch starts once each recycled node is active again, reinstalled in
+place or replaced by a new active node, and every node of the pool is active on
+two polls in a row.  The roll is aborted on the first failure or when a batch
+does not become active within --timeout.  Progress is written to stderr.`
+	rollNPExample = `
+	# Full example
+	vultr-cli kubernetes node-pool roll ffd31f18-5f77-454c-9065-212f942c3c35 abd31f18-3f77-454c-9064-212f942c3c34
+
+	# Recycle two nodes at a time
+	vultr-cli kubernetes node-pool roll ffd31f18-5f77-454c-9065-212f942c3c35 abd31f18-3f77-454c-9064-212f942c3c34 \
+		--max-unavailable=2
+	`
+
+	upgradePlanLong = `Compute the upgrade steps from the cluster version to the target version.
+
+Minor versions cannot be skipped, so each step upgrades to the latest supported
+version of the next minor version until the target is reached.`
+	upgradePlanExample = `
+	# Full example
+	vultr-cli kubernetes upgrades plan d4908765-b82a-4e7d-83d9-c0bc4c6a36d0 --to="v1.31.1+1"
+	`
+
+	upgradeRunLong = `Upgrade the cluster to the target version through the steps given by
+'kubernetes upgrades plan'.
+
+Each step is started once the cluster, its node pools and nodes are active
+again after the previous step.  The run stops on the first step which fails or
+does not complete within --timeout.  Progress is written to stderr.`
+	upgradeRunExample = `
+	# Full example
+	vultr-cli kubernetes upgrades run d4908765-b82a-4e7d-83d9-c0bc4c6a36d0 --to="v1.31.1+1"
+	`
+
 	nodeLong    = `Get all available commands for Kubernetes node pool nodes`
 	nodeExample = `
 	# Full example
@@ -235,6 +306,19 @@ var (
 const (
 	kubeconfigFilePermission = 0600
 	kubeconfigDirPermission  = 0755
+
+	nodeStatusActive   = "active"
+	rollDefaultTimeout = 20 * time.Minute
+	rollPollInterval   = 15 * time.Second
+
+	rollResultRecycled = "recycled"
+	rollResultFailed   = "failed"
+	rollResultSkipped  = "skipped"
+
+	upgradeDefaultTimeout = time.Hour
+	upgradePollInterval   = 30 * time.Second
+	upgradeResultDone     = "upgraded"
+	upgradeResultFailed   = "failed"
 )
 
 // NewCmdKubernetes provides the CLI command for VKE functions
@@ -335,6 +419,50 @@ func NewCmdKubernetes(base *cli.Base) *cobra.Command { //nolint:funlen,gocyclo
 		Example: createExample,
 		Aliases: []string{"c"},
 		RunE: func(cmd *cobra.Command, args []string) error {
+			file, errFi := cmd.Flags().GetString("file")
+			if errFi != nil {
+				return fmt.Errorf("error parsing flag 'file' for kubernetes cluster create : %v", errFi)
+			}
+
+			estimate, errEs := cmd.Flags().GetBool("estimate")
+			if errEs != nil {
+				return fmt.Errorf("error parsing flag 'estimate' for kubernetes cluster create : %v", errEs)
+			}
+
+			if file != "" {
+				if err := utils.FileOnly(cmd, "estimate"); err != nil {
+					return err
+				}
+
+				spec, err := NewClusterSpecFromFile(file)
+				if err != nil {
+					return err
+				}
+
+				if err := spec.Validate(); err != nil {
+					return err
+				}
+
+				if err := spec.ValidateAvailability(o.Base.Context, o.Base.Client); err != nil {
+					return err
+				}
+
+				o.CreateReq = spec.Request()
+
+				if estimate {
+					return o.estimate()
+				}
+
+				k8, err := o.create()
+				if err != nil {
+					return fmt.Errorf("error creating kubernetes cluster : %v", err)
+				}
+
+				o.Base.Printer.Display(&ClusterPrinter{Cluster: k8}, nil)
+
+				return nil
+			}
+
 			label, errLa := cmd.Flags().GetString("label")
 			if errLa != nil {
 				return fmt.Errorf("error parsing flag 'label' for kubernetes cluster create : %v", errLa)
@@ -379,6 +507,10 @@ func NewCmdKubernetes(base *cli.Base) *cobra.Command { //nolint:funlen,gocyclo
 				EnableFirewall:  fw,
 			}
 
+			if estimate {
+				return o.estimate()
+			}
+
 			k8, err := o.create()
 			if err != nil {
 				return fmt.Errorf("error creating kubernetes cluster : %v", err)
@@ -391,23 +523,15 @@ func NewCmdKubernetes(base *cli.Base) *cobra.Command { //nolint:funlen,gocyclo
 		},
 	}
 
+	create.Flags().String("file", "", "(optional) Path to a YAML or JSON spec file with the cluster settings")
 	create.Flags().StringP("label", "l", "", "label for your kubernetes cluster")
-	if err := create.MarkFlagRequired("label"); err != nil {
-		fmt.Printf("error marking kubernetes create 'label' flag required: %v", err)
-		os.Exit(1)
-	}
+	create.MarkFlagsOneRequired("label", "file")
 
 	create.Flags().StringP("region", "r", "", "region you want your kubernetes cluster to be located in")
-	if err := create.MarkFlagRequired("region"); err != nil {
-		fmt.Printf("error marking kubernetes create 'region' flag required: %v", err)
-		os.Exit(1)
-	}
+	create.MarkFlagsOneRequired("region", "file")
 
 	create.Flags().StringP("version", "v", "", "the kubernetes version you want for your cluster")
-	if err := create.MarkFlagRequired("version"); err != nil {
-		fmt.Printf("error marking kubernetes create 'version' flag required: %v", err)
-		os.Exit(1)
-	}
+	create.MarkFlagsOneRequired("version", "file")
 
 	create.Flags().Bool(
 		"high-avail",
@@ -431,10 +555,8 @@ highly available, control planes`,
 required in node pool. Use / between each new node pool.  E.g: 
 'plan:vhf-8c-32gb,label:mynodepool,tag:my-tag,quantity:3/plan:vhf-8c-32gb,label:mynodepool2,quantity:3`,
 	)
-	if err := create.MarkFlagRequired("node-pools"); err != nil {
-		fmt.Printf("error marking kubernetes create 'ns-primary' flag required: %v", err)
-		os.Exit(1)
-	}
+	create.MarkFlagsOneRequired("node-pools", "file")
+	cost.AddFlag(create)
 
 	// Update
 	update := &cobra.Command{
@@ -494,6 +616,21 @@ required in node pool. Use / between each new node pool.  E.g:
 				return fmt.Errorf("error parsing flag 'delete-resource' for kubernetes cluster delete: %v", errRe)
 			}
 
+			if err := utils.ConfirmDelete(cmd, func() (*utils.Resource, error) {
+				cluster, err := o.get()
+				if err != nil {
+					return nil, err
+				}
+
+				r := &utils.Resource{Kind: "kubernetes cluster", ID: cluster.ID, Label: cluster.Label, Region: cluster.Region}
+				if withRes {
+					r.Note = "and all of its related resources"
+				}
+				return r, nil
+			}); err != nil {
+				return err
+			}
+
 			if withRes {
 				if err := o.delWithRes(); err != nil {
 					return fmt.Errorf("error deleting kubernetes cluster and resources : %v", err)
@@ -511,6 +648,7 @@ required in node pool. Use / between each new node pool.  E.g:
 	}
 
 	del.Flags().BoolP("delete-resources", "r", false, "delete a kubernetes cluster and related resources")
+	utils.AddConfirmFlags(del)
 
 	// Config
 	config := &cobra.Command{
@@ -659,9 +797,98 @@ required in node pool. Use / between each new node pool.  E.g:
 		os.Exit(1)
 	}
 
+	// Upgrade Plan
+	upgradePlan := &cobra.Command{
+		Use:     "plan <Cluster ID>",
+		Short:   "Plan the upgrade steps to a kubernetes version",
+		Long:    upgradePlanLong,
+		Example: upgradePlanExample,
+		Aliases: []string{"p"},
+		Args: func(cmd *cobra.Command, args []string) error {
+			if len(args) < 1 {
+				return errors.New("please provide a cluster ID")
+			}
+			return nil
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			to, errTo := cmd.Flags().GetString("to")
+			if errTo != nil {
+				return fmt.Errorf("error parsing flag 'to' for kubernetes upgrade plan : %v", errTo)
+			}
+
+			steps, err := o.upgradePlan(to)
+			if err != nil {
+				return err
+			}
+
+			o.Base.Printer.Display(&UpgradePlanPrinter{Steps: steps}, nil)
+
+			return nil
+		},
+	}
+
+	upgradePlan.Flags().String("to", "", "the version to upgrade the cluster to")
+	if err := upgradePlan.MarkFlagRequired("to"); err != nil {
+		fmt.Printf("error marking kubernetes upgrade plan 'to' flag required: %v", err)
+		os.Exit(1)
+	}
+
+	// Upgrade Run
+	upgradeRun := &cobra.Command{
+		Use:     "run <Cluster ID>",
+		Short:   "Upgrade a cluster to a kubernetes version one step at a time",
+		Long:    upgradeRunLong,
+		Example: upgradeRunExample,
+		Aliases: []string{"r"},
+		Args: func(cmd *cobra.Command, args []string) error {
+			if len(args) < 1 {
+				return errors.New("please provide a cluster ID")
+			}
+			return nil
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			to, errTo := cmd.Flags().GetString("to")
+			if errTo != nil {
+				return fmt.Errorf("error parsing flag 'to' for kubernetes upgrade run : %v", errTo)
+			}
+
+			timeout, errTi := cmd.Flags().GetDuration("timeout")
+			if errTi != nil {
+				return fmt.Errorf("error parsing flag 'timeout' for kubernetes upgrade run : %v", errTi)
+			}
+
+			steps, err := o.upgradePlan(to)
+			if err != nil {
+				return err
+			}
+
+			errRun := o.upgradeRun(steps, timeout)
+			o.Base.Printer.Print(&UpgradePlanPrinter{Steps: steps})
+
+			if errRun != nil {
+				return fmt.Errorf("error upgrading kubernetes cluster : %v", errRun)
+			}
+
+			return nil
+		},
+	}
+
+	upgradeRun.Flags().String("to", "", "the version to upgrade the cluster to")
+	if err := upgradeRun.MarkFlagRequired("to"); err != nil {
+		fmt.Printf("error marking kubernetes upgrade run 'to' flag required: %v", err)
+		os.Exit(1)
+	}
+	upgradeRun.Flags().Duration(
+		"timeout",
+		upgradeDefaultTimeout,
+		"(optional) The maximum time to wait for each step to complete",
+	)
+
 	upgrades.AddCommand(
 		upgradesList,
 		upgradeStart,
+		upgradePlan,
+		upgradeRun,
 	)
 
 	// Node Pools
@@ -1001,6 +1228,53 @@ required in node pool. Use / between each new node pool.  E.g:
 		},
 	}
 
+	// Node Pool Roll
+	npRoll := &cobra.Command{
+		Use:     "roll <Cluster ID> <Node Pool ID>",
+		Short:   "Recycle all nodes in a node pool in batches",
+		Long:    rollNPLong,
+		Example: rollNPExample,
+		Args: func(cmd *cobra.Command, args []string) error {
+			if len(args) < 2 {
+				return errors.New("please provide a cluster ID and node pool ID")
+			}
+			return nil
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			maxUnavailable, errMu := cmd.Flags().GetInt("max-unavailable")
+			if errMu != nil {
+				return fmt.Errorf("error parsing flag 'max-unavailable' for kubernetes node pool roll : %v", errMu)
+			}
+
+			if maxUnavailable < 1 {
+				return errors.New("max-unavailable must be at least 1")
+			}
+
+			timeout, errTi := cmd.Flags().GetDuration("timeout")
+			if errTi != nil {
+				return fmt.Errorf("error parsing flag 'timeout' for kubernetes node pool roll : %v", errTi)
+			}
+
+			results, err := o.nodePoolRoll(maxUnavailable, timeout)
+			if results != nil {
+				o.Base.Printer.Print(&RollPrinter{Results: results})
+			}
+
+			if err != nil {
+				return fmt.Errorf("error rolling kubernetes node pool : %v", err)
+			}
+
+			return nil
+		},
+	}
+
+	npRoll.Flags().Int("max-unavailable", 1, "(optional) The number of nodes recycled at the same time")
+	npRoll.Flags().Duration(
+		"timeout",
+		rollDefaultTimeout,
+		"(optional) The maximum time to wait for each batch of nodes to become active",
+	)
+
 	// Node
 	node := &cobra.Command{
 		Use:     "node",
@@ -1068,6 +1342,7 @@ required in node pool. Use / between each new node pool.  E.g:
 		npCreate,
 		npUpdate,
 		npDelete,
+		npRoll,
 		node,
 	)
 
@@ -1201,6 +1476,18 @@ func (o *options) create() (*govultr.Cluster, error) {
 	return k8, err
 }
 
+// estimate displays the estimated cost of the node pools in the create request
+func (o *options) estimate() error {
+	items, err := cost.NewEstimator(o.Base).Kubernetes(o.CreateReq.Region, o.CreateReq.NodePools)
+	if err != nil {
+		return fmt.Errorf("error estimating kubernetes cluster cost : %v", err)
+	}
+
+	o.Base.Printer.Display(&cost.EstimatePrinter{Estimate: cost.NewEstimate(items...)}, nil)
+
+	return nil
+}
+
 func (o *options) update() error {
 	return o.Base.Client.Kubernetes.UpdateCluster(o.Base.Context, o.Base.Args[0], o.UpdateReq)
 }
@@ -1263,3 +1550,249 @@ func (o *options) nodePoolNodeDelete() error {
 func (o *options) nodePoolNodeRecycle() error {
 	return o.Base.Client.Kubernetes.RecycleNodePoolInstance(o.Base.Context, o.Base.Args[0], o.Base.Args[1], o.Base.Args[2]) //nolint:lll
 }
+
+// nodePoolRoll recycles the nodes of the node pool in batches of at most
+// maxUnavailable nodes, waiting for each batch to become active before
+// starting the next.  The results cover every node, with the nodes after a
+// failure marked as skipped.
+func (o *options) nodePoolRoll(maxUnavailable int, timeout time.Duration) ([]RollResult, error) {
+	np, err := o.nodePool()
+	if err != nil {
+		return nil, fmt.Errorf("error getting node pool : %v", err)
+	}
+
+	for i := range np.Nodes {
+		if np.Nodes[i].Status != nodeStatusActive {
+			return nil, fmt.Errorf(
+				"node %s is %s, every node must be active to start the roll",
+				np.Nodes[i].ID,
+				np.Nodes[i].Status,
+			)
+		}
+	}
+
+	results := make([]RollResult, len(np.Nodes))
+	for i := range np.Nodes {
+		results[i] = RollResult{ID: np.Nodes[i].ID, Label: np.Nodes[i].Label, Result: rollResultSkipped}
+	}
+
+	for start := 0; start < len(np.Nodes); start += maxUnavailable {
+		end := min(start+maxUnavailable, len(np.Nodes))
+		batch := np.Nodes[start:end]
+		started := time.Now()
+
+		// The nodes before the batch tell its replacements apart from those of
+		// the previous batches
+		before, err := o.nodePool()
+		if err != nil {
+			return results, fmt.Errorf("error getting node pool : %v", err)
+		}
+
+		for i := range batch {
+			fmt.Fprintf(os.Stderr, "[%d/%d] recycling node %s (%s)\n", start+i+1, len(np.Nodes), batch[i].ID, batch[i].Label)
+			if err := o.Base.Client.Kubernetes.RecycleNodePoolInstance(
+				o.Base.Context,
+				o.Base.Args[0],
+				o.Base.Args[1],
+				batch[i].ID,
+			); err != nil {
+				results[start+i].Result = rollResultFailed
+				results[start+i].Error = err.Error()
+				return results, fmt.Errorf("error recycling node %s : %v", batch[i].ID, err)
+			}
+		}
+
+		if err := o.waitNodesRecycled(before.Nodes, batch, timeout); err != nil {
+			for i := range batch {
+				results[start+i].Result = rollResultFailed
+				results[start+i].Error = err.Error()
+			}
+			return results, err
+		}
+
+		for i := range batch {
+			results[start+i].Result = rollResultRecycled
+			results[start+i].Duration = time.Since(started).Round(time.Second).String()
+		}
+		fmt.Fprintf(os.Stderr, "[%d/%d] node pool is active\n", end, len(np.Nodes))
+	}
+
+	return results, nil
+}
+
+// waitNodesRecycled polls the node pool until every recycled node is active
+// again, either reinstalled in place or replaced by a new active node, and
+// every node of the pool is active on two polls in a row.  The second poll
+// guards against the pool briefly reporting active before the replacement
+// nodes are listed.
+func (o *options) waitNodesRecycled(original, recycled []govultr.Node, timeout time.Duration) error {
+	known := map[string]bool{}
+	for i := range original {
+		known[original[i].ID] = true
+	}
+
+	left := map[string]bool{}
+	deadline := time.Now().Add(timeout)
+	settled := false
+
+	for {
+		time.Sleep(rollPollInterval)
+
+		np, err := o.nodePool()
+		if err != nil {
+			return fmt.Errorf("error getting node pool : %v", err)
+		}
+
+		current := map[string]govultr.Node{}
+		active, replacements := 0, 0
+		for i := range np.Nodes {
+			current[np.Nodes[i].ID] = np.Nodes[i]
+			if np.Nodes[i].Status != nodeStatusActive {
+				continue
+			}
+			active++
+			if !known[np.Nodes[i].ID] {
+				replacements++
+			}
+		}
+
+		// A node reinstalled in place is done once it was seen leaving the
+		// active state, or was recreated, and is active again.  A removed node
+		// needs an active replacement.
+		done, removed := 0, 0
+		for i := range recycled {
+			n, ok := current[recycled[i].ID]
+			switch {
+			case !ok:
+				removed++
+			case n.Status != nodeStatusActive:
+				left[n.ID] = true
+			case left[n.ID] || n.DateCreated != recycled[i].DateCreated:
+				done++
+			}
+		}
+
+		ready := done+removed == len(recycled) &&
+			replacements >= removed &&
+			len(np.Nodes) >= len(original) &&
+			active == len(np.Nodes)
+		if ready && settled {
+			return nil
+		}
+		settled = ready
+
+		if time.Now().After(deadline) {
+			return fmt.Errorf(
+				"timed out after %s waiting for the recycled nodes, %d of %d nodes are active",
+				timeout,
+				active,
+				len(np.Nodes),
+			)
+		}
+	}
+}
+
+// upgradePlan computes the upgrade steps of the cluster to the target version
+func (o *options) upgradePlan(target string) ([]UpgradeStep, error) {
+	k8, err := o.get()
+	if err != nil {
+		return nil, fmt.Errorf("error retrieving kubernetes cluster : %v", err)
+	}
+
+	available, err := o.upgrades()
+	if err != nil {
+		return nil, fmt.Errorf("error retrieving the available kubernetes upgrades : %v", err)
+	}
+
+	supported, err := o.versions()
+	if err != nil {
+		return nil, fmt.Errorf("error retrieving kubernetes versions : %v", err)
+	}
+
+	return planUpgrade(k8.Version, target, available, supported.Versions)
+}
+
+// upgradeRun starts each upgrade step and waits for the cluster to be healthy
+// before the next.  The results are recorded on the steps.
+func (o *options) upgradeRun(steps []UpgradeStep, timeout time.Duration) error {
+	for i := range steps {
+		started := time.Now()
+		fmt.Fprintf(os.Stderr, "[%d/%d] upgrading from %s to %s\n", i+1, len(steps), steps[i].From, steps[i].To)
+
+		err := o.upgradeStep(steps[i].To, timeout)
+		steps[i].Duration = time.Since(started).Round(time.Second).String()
+		if err != nil {
+			steps[i].Result = upgradeResultFailed
+			steps[i].Error = err.Error()
+			return fmt.Errorf("step %d to %s failed : %v", steps[i].Step, steps[i].To, err)
+		}
+
+		steps[i].Result = upgradeResultDone
+		fmt.Fprintf(os.Stderr, "[%d/%d] cluster is active on %s\n", i+1, len(steps), steps[i].To)
+	}
+
+	return nil
+}
+
+// upgradeStep starts the upgrade to the version, once it is available to the
+// cluster, and waits for the cluster to run the version and be healthy
+func (o *options) upgradeStep(to string, timeout time.Duration) error {
+	available, err := o.upgrades()
+	if err != nil {
+		return fmt.Errorf("error retrieving the available kubernetes upgrades : %v", err)
+	}
+
+	if !slices.Contains(available, to) {
+		return fmt.Errorf("the upgrade is not available, available upgrades are: %s", strings.Join(available, ", "))
+	}
+
+	o.UpgradeReq = &govultr.ClusterUpgradeReq{UpgradeVersion: to}
+	if err := o.upgrade(); err != nil {
+		return fmt.Errorf("error starting the kubernetes upgrade : %v", err)
+	}
+
+	deadline := time.Now().Add(timeout)
+	for {
+		time.Sleep(upgradePollInterval)
+
+		k8, err := o.get()
+		if err != nil {
+			return fmt.Errorf("error retrieving kubernetes cluster : %v", err)
+		}
+
+		if k8.Version == to && clusterHealthy(k8) {
+			return nil
+		}
+
+		if time.Now().After(deadline) {
+			return fmt.Errorf(
+				"timed out after %s waiting for the cluster, it is %s on version %s",
+				timeout,
+				k8.Status,
+				k8.Version,
+			)
+		}
+	}
+}
+
+// clusterHealthy returns whether the cluster, its node pools and nodes are
+// all active
+func clusterHealthy(k8 *govultr.Cluster) bool {
+	if k8.Status != nodeStatusActive {
+		return false
+	}
+
+	for i := range k8.NodePools {
+		if k8.NodePools[i].Status != nodeStatusActive {
+			return false
+		}
+
+		for j := range k8.NodePools[i].Nodes {
+			if k8.NodePools[i].Nodes[j].Status != nodeStatusActive {
+				return false
+			}
+		}
+	}
+
+	return true
+}
@@ -599,3 +599,120 @@ func (c *ConfigPrinter) Data() [][]string {
 func (c *ConfigPrinter) Paging() [][]string {
 	return nil
 }
+
+// ======================================
+
+// RollResult is the outcome of recycling a node during a node pool roll
+type RollResult struct {
+	ID       string `json:"id"`
+	Label    string `json:"label"`
+	Result   string `json:"result"`
+	Duration string `json:"duration,omitempty"`
+	Error    string `json:"error,omitempty"`
+}
+
+// RollPrinter ...
+type RollPrinter struct {
+	Results []RollResult `json:"nodes"`
+}
+
+// JSON ...
+func (r *RollPrinter) JSON() []byte {
+	return printer.MarshalObject(r, "json")
+}
+
+// YAML ...
+func (r *RollPrinter) YAML() []byte {
+	return printer.MarshalObject(r, "yaml")
+}
+
+// Columns ...
+func (r *RollPrinter) Columns() [][]string {
+	return [][]string{0: {
+		"ID",
+		"LABEL",
+		"RESULT",
+		"DURATION",
+		"ERROR",
+	}}
+}
+
+// Data ...
+func (r *RollPrinter) Data() [][]string {
+	if len(r.Results) == 0 {
+		return [][]string{0: {"---", "---", "---", "---", "---"}}
+	}
+
+	var data [][]string
+	for i := range r.Results {
+		data = append(data, []string{
+			r.Results[i].ID,
+			r.Results[i].Label,
+			r.Results[i].Result,
+			r.Results[i].Duration,
+			r.Results[i].Error,
+		})
+	}
+
+	return data
+}
+
+// Paging ...
+func (r *RollPrinter) Paging() [][]string {
+	return nil
+}
+
+// ======================================
+
+// UpgradePlanPrinter ...
+type UpgradePlanPrinter struct {
+	Steps []UpgradeStep `json:"steps"`
+}
+
+// JSON ...
+func (u *UpgradePlanPrinter) JSON() []byte {
+	return printer.MarshalObject(u, "json")
+}
+
+// YAML ...
+func (u *UpgradePlanPrinter) YAML() []byte {
+	return printer.MarshalObject(u, "yaml")
+}
+
+// Columns ...
+func (u *UpgradePlanPrinter) Columns() [][]string {
+	return [][]string{0: {
+		"STEP",
+		"FROM",
+		"TO",
+		"RESULT",
+		"DURATION",
+		"ERROR",
+	}}
+}
+
+// Data ...
+func (u *UpgradePlanPrinter) Data() [][]string {
+	if len(u.Steps) == 0 {
+		return [][]string{0: {"---", "---", "---", "---", "---", "---"}}
+	}
+
+	var data [][]string
+	for i := range u.Steps {
+		data = append(data, []string{
+			strconv.Itoa(u.Steps[i].Step),
+			u.Steps[i].From,
+			u.Steps[i].To,
+			u.Steps[i].Result,
+			u.Steps[i].Duration,
+			u.Steps[i].Error,
+		})
+	}
+
+	return data
+}
+
+// Paging ...
+func (u *UpgradePlanPrinter) Paging() [][]string {
+	return nil
+}
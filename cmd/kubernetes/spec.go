@@ -0,0 +1,246 @@
+package kubernetes
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/vultr/govultr/v3"
+	"gopkg.in/yaml.v3"
+)
+
+var taintEffects = []string{"NoSchedule", "PreferNoSchedule", "NoExecute"}
+
+// ClusterSpec is the structured document used to create a cluster with the
+// --file flag
+type ClusterSpec struct {
+	Label           string         `yaml:"label"`
+	Region          string         `yaml:"region"`
+	Version         string         `yaml:"version"`
+	HAControlPlanes bool           `yaml:"ha_control_planes,omitempty"`
+	EnableFirewall  bool           `yaml:"enable_firewall,omitempty"`
+	VPC             string         `yaml:"vpc,omitempty"`
+	OIDC            *OIDCSpec      `yaml:"oidc,omitempty"`
+	NodePools       []NodePoolSpec `yaml:"node_pools"`
+}
+
+// OIDCSpec is the OIDC section of a ClusterSpec
+type OIDCSpec struct {
+	IssuerURL     string `yaml:"issuer_url"`
+	ClientID      string `yaml:"client_id"`
+	UsernameClaim string `yaml:"username_claim,omitempty"`
+	GroupsClaim   string `yaml:"groups_claim,omitempty"`
+}
+
+// NodePoolSpec is a node pool of a ClusterSpec
+type NodePoolSpec struct {
+	Label      string            `yaml:"label"`
+	Plan       string            `yaml:"plan"`
+	Quantity   int               `yaml:"quantity"`
+	Tag        string            `yaml:"tag,omitempty"`
+	AutoScaler bool              `yaml:"auto_scaler,omitempty"`
+	MinNodes   int               `yaml:"min_nodes,omitempty"`
+	MaxNodes   int               `yaml:"max_nodes,omitempty"`
+	VPCOnly    *bool             `yaml:"vpc_only,omitempty"`
+	Labels     map[string]string `yaml:"labels,omitempty"`
+	Taints     []TaintSpec       `yaml:"taints,omitempty"`
+}
+
+// TaintSpec is a node pool taint of a ClusterSpec
+type TaintSpec struct {
+	Key    string `yaml:"key"`
+	Value  string `yaml:"value,omitempty"`
+	Effect string `yaml:"effect"`
+}
+
+// NewClusterSpecFromFile reads in a YAML or JSON cluster spec file.  Unknown
+// fields are an error to catch typos before calling the API.
+func NewClusterSpecFromFile(path string) (*ClusterSpec, error) {
+	fd, err := os.ReadFile(filepath.Clean(path))
+	if err != nil {
+		return nil, fmt.Errorf("error reading cluster spec file : %v", err)
+	}
+
+	dec := yaml.NewDecoder(bytes.NewReader(fd))
+	dec.KnownFields(true)
+
+	spec := &ClusterSpec{}
+	if err := dec.Decode(spec); err != nil {
+		return nil, fmt.Errorf("error parsing cluster spec file : %v", err)
+	}
+
+	return spec, nil
+}
+
+// Validate checks the spec locally without calling the API
+func (s *ClusterSpec) Validate() error { //nolint:gocyclo
+	var errs []string
+	addErr := func(format string, a ...interface{}) {
+		errs = append(errs, fmt.Sprintf(format, a...))
+	}
+
+	if s.Label == "" {
+		addErr("label is required")
+	}
+
+	if s.Region == "" {
+		addErr("region is required")
+	}
+
+	if s.Version == "" {
+		addErr("version is required")
+	}
+
+	if s.OIDC != nil && (s.OIDC.IssuerURL == "" || s.OIDC.ClientID == "") {
+		addErr("oidc requires an issuer_url and a client_id")
+	}
+
+	if len(s.NodePools) == 0 {
+		addErr("at least one node pool is required")
+	}
+
+	labels := map[string]bool{}
+	for i := range s.NodePools {
+		np := &s.NodePools[i]
+		name := fmt.Sprintf("node_pools[%d]", i)
+
+		if np.Label == "" {
+			addErr("%s label is required", name)
+		} else if labels[np.Label] {
+			addErr("%s label %s is used by more than one node pool", name, np.Label)
+		}
+		labels[np.Label] = true
+
+		if np.Plan == "" {
+			addErr("%s plan is required", name)
+		}
+
+		if np.Quantity < 1 {
+			addErr("%s quantity must be at least 1", name)
+		}
+
+		if np.AutoScaler {
+			if np.MinNodes < 1 || np.MaxNodes < np.MinNodes {
+				addErr("%s auto_scaler requires min_nodes of at least 1 and max_nodes of at least min_nodes", name)
+			} else if np.Quantity < np.MinNodes || np.Quantity > np.MaxNodes {
+				addErr("%s quantity must be between min_nodes and max_nodes", name)
+			}
+		} else if np.MinNodes != 0 || np.MaxNodes != 0 {
+			addErr("%s min_nodes and max_nodes require auto_scaler", name)
+		}
+
+		for k := range np.Labels {
+			if k == "" {
+				addErr("%s labels cannot have an empty key", name)
+			}
+		}
+
+		for j := range np.Taints {
+			if np.Taints[j].Key == "" {
+				addErr("%s taints[%d] key is required", name, j)
+			}
+
+			if !slices.Contains(taintEffects, np.Taints[j].Effect) {
+				addErr("%s taints[%d] effect must be one of %s", name, j, strings.Join(taintEffects, ", "))
+			}
+		}
+	}
+
+	if len(errs) > 0 {
+		return fmt.Errorf("invalid cluster spec :\n  %s", strings.Join(errs, "\n  "))
+	}
+
+	return nil
+}
+
+// ValidateAvailability checks the version against the supported kubernetes
+// versions and the node pool plans against the plans available in the region
+func (s *ClusterSpec) ValidateAvailability(ctx context.Context, client *govultr.Client) error {
+	versions, _, err := client.Kubernetes.GetVersions(ctx)
+	if err != nil {
+		return fmt.Errorf("error retrieving kubernetes versions : %v", err)
+	}
+
+	var errs []string
+	if !slices.Contains(versions.Versions, s.Version) {
+		errs = append(errs, fmt.Sprintf(
+			"version %s is not supported, must be one of %s",
+			s.Version,
+			strings.Join(versions.Versions, ", "),
+		))
+	}
+
+	avail, _, err := client.Region.Availability(ctx, s.Region, "all")
+	if err != nil {
+		return fmt.Errorf("error retrieving plan availability for region %s : %v", s.Region, err)
+	}
+
+	for i := range s.NodePools {
+		if !slices.Contains(avail.AvailablePlans, s.NodePools[i].Plan) {
+			errs = append(errs, fmt.Sprintf(
+				"node_pools[%d] plan %s is not available in region %s",
+				i,
+				s.NodePools[i].Plan,
+				s.Region,
+			))
+		}
+	}
+
+	if len(errs) > 0 {
+		return fmt.Errorf("invalid cluster spec :\n  %s", strings.Join(errs, "\n  "))
+	}
+
+	return nil
+}
+
+// Request converts the spec into the API request
+func (s *ClusterSpec) Request() *govultr.ClusterReq {
+	req := &govultr.ClusterReq{
+		Label:           s.Label,
+		Region:          s.Region,
+		Version:         s.Version,
+		HAControlPlanes: s.HAControlPlanes,
+		EnableFirewall:  s.EnableFirewall,
+		VPCID:           s.VPC,
+	}
+
+	if s.OIDC != nil {
+		req.OIDCConfig = &govultr.ClusterOIDCConfig{
+			IssuerURL:     s.OIDC.IssuerURL,
+			ClientID:      s.OIDC.ClientID,
+			UserNameClaim: s.OIDC.UsernameClaim,
+			GroupsClaim:   s.OIDC.GroupsClaim,
+		}
+	}
+
+	for i := range s.NodePools {
+		np := &s.NodePools[i]
+		npReq := govultr.NodePoolReq{
+			NodeQuantity: np.Quantity,
+			Label:        np.Label,
+			Plan:         np.Plan,
+			Tag:          np.Tag,
+			MinNodes:     np.MinNodes,
+			MaxNodes:     np.MaxNodes,
+			AutoScaler:   govultr.BoolToBoolPtr(np.AutoScaler),
+			VPCOnly:      np.VPCOnly,
+			Labels:       np.Labels,
+		}
+
+		for j := range np.Taints {
+			npReq.Taints = append(npReq.Taints, govultr.Taint{
+				Key:    np.Taints[j].Key,
+				Value:  np.Taints[j].Value,
+				Effect: np.Taints[j].Effect,
+			})
+		}
+
+		req.NodePools = append(req.NodePools, npReq)
+	}
+
+	return req
+}
@@ -0,0 +1,153 @@
+package kubernetes
+
+import (
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// version is a parsed VKE version such as v1.29.2+1
+type version struct {
+	raw                        string
+	major, minor, patch, build int
+}
+
+// parseVersion parses a VKE version, where the leading v and the build
+// suffix are optional
+func parseVersion(s string) (version, error) {
+	v := version{raw: s}
+
+	rest := strings.TrimPrefix(strings.TrimSpace(s), "v")
+	rest, build, hasBuild := strings.Cut(rest, "+")
+	if hasBuild {
+		b, err := strconv.Atoi(build)
+		if err != nil {
+			return v, fmt.Errorf("invalid kubernetes version %q", s)
+		}
+		v.build = b
+	}
+
+	parts := strings.Split(rest, ".")
+	if len(parts) != 3 { //nolint:mnd
+		return v, fmt.Errorf("invalid kubernetes version %q", s)
+	}
+
+	nums := make([]int, len(parts))
+	for i := range parts {
+		n, err := strconv.Atoi(parts[i])
+		if err != nil {
+			return v, fmt.Errorf("invalid kubernetes version %q", s)
+		}
+		nums[i] = n
+	}
+	v.major, v.minor, v.patch = nums[0], nums[1], nums[2]
+
+	return v, nil
+}
+
+// compare returns -1, 0 or 1 when v is lower than, equal to or higher than w
+func (v version) compare(w version) int {
+	for _, d := range []int{v.major - w.major, v.minor - w.minor, v.patch - w.patch, v.build - w.build} {
+		if d < 0 {
+			return -1
+		} else if d > 0 {
+			return 1
+		}
+	}
+	return 0
+}
+
+// UpgradeStep is a single version upgrade of an upgrade plan
+type UpgradeStep struct {
+	Step     int    `json:"step"`
+	From     string `json:"from"`
+	To       string `json:"to"`
+	Result   string `json:"result,omitempty"`
+	Duration string `json:"duration,omitempty"`
+	Error    string `json:"error,omitempty"`
+}
+
+// planUpgrade computes the upgrade steps from the current version to the
+// target.  Minor versions cannot be skipped, so each step moves to the latest
+// supported version of the next minor version, with the target as the last
+// step.  The first step must be one of the upgrades currently available to the
+// cluster while the later steps are taken from the supported versions.
+func planUpgrade(current, target string, available, supported []string) ([]UpgradeStep, error) {
+	cur, err := parseVersion(current)
+	if err != nil {
+		return nil, err
+	}
+
+	tgt, err := parseVersion(target)
+	if err != nil {
+		return nil, err
+	}
+
+	if tgt.compare(cur) <= 0 {
+		return nil, fmt.Errorf("the target version %s is not newer than the cluster version %s", target, current)
+	}
+
+	if tgt.major != cur.major {
+		return nil, fmt.Errorf("upgrading across major versions, from %s to %s, is not supported", current, target)
+	}
+
+	if !slices.Contains(supported, target) && !slices.Contains(available, target) {
+		return nil, fmt.Errorf("the target version %s is not a supported kubernetes version", target)
+	}
+
+	var steps []UpgradeStep
+	from := cur
+	for from.compare(tgt) < 0 {
+		candidates := supported
+		if len(steps) == 0 {
+			candidates = available
+		}
+
+		next, ok := nextStep(from, tgt, candidates)
+		if !ok {
+			if len(steps) == 0 {
+				return nil, fmt.Errorf(
+					"no upgrade towards %s is available for the cluster version %s, available upgrades are: %s",
+					target,
+					current,
+					strings.Join(available, ", "),
+				)
+			}
+			return nil, fmt.Errorf("no supported version of %d.%d to upgrade %s to", from.major, from.minor+1, from.raw)
+		}
+
+		steps = append(steps, UpgradeStep{Step: len(steps) + 1, From: from.raw, To: next.raw})
+		from = next
+	}
+
+	return steps, nil
+}
+
+// nextStep returns the target when it is in the next minor version or in the
+// current one, otherwise the latest candidate of the next minor version
+func nextStep(from, target version, candidates []string) (version, bool) {
+	nextMinor := from.minor + 1
+	if target.minor <= nextMinor {
+		for i := range candidates {
+			if candidates[i] == target.raw {
+				return target, true
+			}
+		}
+	}
+
+	var best version
+	found := false
+	for i := range candidates {
+		v, err := parseVersion(candidates[i])
+		if err != nil || v.major != from.major || v.minor != nextMinor || v.compare(target) > 0 {
+			continue
+		}
+
+		if !found || v.compare(best) > 0 {
+			best, found = v, true
+		}
+	}
+
+	return best, found
+}
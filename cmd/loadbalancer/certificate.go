@@ -0,0 +1,239 @@
+package loadbalancer
+
+import (
+	"bytes"
+	"crypto"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"math"
+	"net"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/vultr/govultr/v3"
+)
+
+const (
+	certStatusOK       string = "ok"
+	certStatusExpiring string = "expiring"
+	certStatusExpired  string = "expired"
+	certStatusNotYet   string = "not yet valid"
+
+	certCheckDefaultDays int           = 30
+	certCheckDialTimeout time.Duration = 10 * time.Second
+	certDefaultPort      int           = 443
+	hoursPerDay          float64       = 24
+)
+
+// Certificate summarizes a certificate and its chain for display
+type Certificate struct {
+	Subject       string    `json:"subject"`
+	Issuer        string    `json:"issuer"`
+	SANs          []string  `json:"sans"`
+	NotBefore     time.Time `json:"not_before"`
+	NotAfter      time.Time `json:"not_after"`
+	DaysRemaining int       `json:"days_remaining"`
+	Chain         []string  `json:"chain"`
+	Status        string    `json:"status"`
+}
+
+// newCertificate summarizes the leaf certificate and chain.  The status is
+// expiring when the certificate expires within the number of days.
+func newCertificate(leaf *x509.Certificate, chain []*x509.Certificate, days int, now time.Time) *Certificate {
+	c := &Certificate{
+		Subject:       leaf.Subject.String(),
+		Issuer:        leaf.Issuer.String(),
+		SANs:          certSANs(leaf),
+		NotBefore:     leaf.NotBefore,
+		NotAfter:      leaf.NotAfter,
+		DaysRemaining: int(math.Floor(leaf.NotAfter.Sub(now).Hours() / hoursPerDay)),
+		Status:        certStatusOK,
+	}
+
+	for i := range chain {
+		c.Chain = append(c.Chain, chain[i].Subject.String())
+	}
+
+	switch {
+	case now.After(leaf.NotAfter):
+		c.Status = certStatusExpired
+	case now.Before(leaf.NotBefore):
+		c.Status = certStatusNotYet
+	case c.DaysRemaining < days:
+		c.Status = certStatusExpiring
+	}
+
+	return c
+}
+
+// certSANs returns the DNS names and IP addresses the certificate is valid for
+func certSANs(cert *x509.Certificate) []string {
+	sans := append([]string{}, cert.DNSNames...)
+	for i := range cert.IPAddresses {
+		sans = append(sans, cert.IPAddresses[i].String())
+	}
+	return sans
+}
+
+// parseCertificates decodes every certificate in the PEM data
+func parseCertificates(data []byte) ([]*x509.Certificate, error) {
+	var certs []*x509.Certificate
+	for {
+		var block *pem.Block
+		block, data = pem.Decode(data)
+		if block == nil {
+			break
+		}
+
+		if block.Type != "CERTIFICATE" {
+			continue
+		}
+
+		cert, err := x509.ParseCertificate(block.Bytes)
+		if err != nil {
+			return nil, fmt.Errorf("error parsing certificate : %v", err)
+		}
+		certs = append(certs, cert)
+	}
+
+	if len(certs) == 0 {
+		return nil, errors.New("no PEM encoded certificate found")
+	}
+
+	return certs, nil
+}
+
+// parsePrivateKey decodes a PKCS #1, PKCS #8 or EC private key from the PEM data
+func parsePrivateKey(data []byte) (crypto.Signer, error) {
+	for {
+		var block *pem.Block
+		block, data = pem.Decode(data)
+		if block == nil {
+			return nil, errors.New("no PEM encoded private key found")
+		}
+
+		switch block.Type {
+		case "RSA PRIVATE KEY":
+			return x509.ParsePKCS1PrivateKey(block.Bytes)
+		case "EC PRIVATE KEY":
+			return x509.ParseECPrivateKey(block.Bytes)
+		case "PRIVATE KEY":
+			key, err := x509.ParsePKCS8PrivateKey(block.Bytes)
+			if err != nil {
+				return nil, err
+			}
+
+			signer, ok := key.(crypto.Signer)
+			if !ok {
+				return nil, errors.New("unsupported private key type")
+			}
+			return signer, nil
+		}
+	}
+}
+
+// decodeBase64PEM decodes the contents of a Base64-encoded PEM file
+func decodeBase64PEM(data []byte) ([]byte, error) {
+	decoded, err := base64.StdEncoding.DecodeString(string(bytes.TrimSpace(data)))
+	if err != nil {
+		return nil, fmt.Errorf("error decoding Base64 value : %v", err)
+	}
+	return decoded, nil
+}
+
+// validateCertificate parses the PEM material and checks that the private key
+// matches the certificate and that each certificate of the chain is signed by
+// the next.  A certificate file containing the full chain is also accepted.
+// Expired or not yet valid certificates are an error unless force is set.
+func validateCertificate(certPEM, keyPEM, chainPEM []byte, force bool) (*Certificate, error) {
+	certs, err := parseCertificates(certPEM)
+	if err != nil {
+		return nil, fmt.Errorf("invalid certificate : %v", err)
+	}
+	leaf, chain := certs[0], certs[1:]
+
+	if len(bytes.TrimSpace(chainPEM)) > 0 {
+		extra, errCh := parseCertificates(chainPEM)
+		if errCh != nil {
+			return nil, fmt.Errorf("invalid certificate chain : %v", errCh)
+		}
+		chain = append(chain, extra...)
+	}
+
+	key, err := parsePrivateKey(keyPEM)
+	if err != nil {
+		return nil, fmt.Errorf("invalid private key : %v", err)
+	}
+
+	pub, ok := key.Public().(interface{ Equal(crypto.PublicKey) bool })
+	if !ok || !pub.Equal(leaf.PublicKey) {
+		return nil, errors.New("the private key does not match the certificate")
+	}
+
+	issued := leaf
+	for i := range chain {
+		if err := issued.CheckSignatureFrom(chain[i]); err != nil {
+			return nil, fmt.Errorf(
+				"the certificate chain is out of order : %q is not signed by %q",
+				issued.Subject.String(),
+				chain[i].Subject.String(),
+			)
+		}
+		issued = chain[i]
+	}
+
+	cert := newCertificate(leaf, chain, 0, time.Now())
+	if !force {
+		switch cert.Status {
+		case certStatusExpired:
+			return nil, fmt.Errorf(
+				"the certificate expired on %s, use --force to upload it anyway",
+				leaf.NotAfter.Format(time.RFC3339),
+			)
+		case certStatusNotYet:
+			return nil, fmt.Errorf(
+				"the certificate is not valid before %s, use --force to upload it anyway",
+				leaf.NotBefore.Format(time.RFC3339),
+			)
+		}
+	}
+
+	return cert, nil
+}
+
+// fetchCertificate retrieves the certificate served on the address through a
+// TLS handshake
+func fetchCertificate(host string, port int, serverName string) (*x509.Certificate, []*x509.Certificate, error) {
+	dialer := &net.Dialer{Timeout: certCheckDialTimeout}
+	conn, err := tls.DialWithDialer(dialer, "tcp", net.JoinHostPort(host, strconv.Itoa(port)), &tls.Config{
+		ServerName: serverName,
+		// The certificate is only inspected, trust is not required
+		InsecureSkipVerify: true, //nolint:gosec
+	})
+	if err != nil {
+		return nil, nil, fmt.Errorf("error connecting to %s : %v", host, err)
+	}
+	defer conn.Close() //nolint:errcheck
+
+	certs := conn.ConnectionState().PeerCertificates
+	if len(certs) == 0 {
+		return nil, nil, fmt.Errorf("no certificate was served by %s", host)
+	}
+
+	return certs[0], certs[1:], nil
+}
+
+// httpsPort returns the first HTTPS frontend port of the forwarding rules
+func httpsPort(rules []govultr.ForwardingRule) int {
+	for i := range rules {
+		if strings.EqualFold(rules[i].FrontendProtocol, "https") {
+			return rules[i].FrontendPort
+		}
+	}
+	return certDefaultPort
+}
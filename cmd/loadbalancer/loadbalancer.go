@@ -6,8 +6,10 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"slices"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/spf13/cobra"
 	"github.com/vultr/govultr/v3"
@@ -38,7 +40,20 @@ var (
 	vultr-cli load-balancer list --summarize
 	`
 
-	createLong    = `Create a new Load Balancer with the desired settings`
+	getLong    = `Retrieve a load balancer`
+	getExample = `
+	# Full example
+	vultr-cli load-balancer get 57539f6f-66a2-4580-936b-d0af934bce5d
+
+	# Output the load balancer as a spec file which can be used with create or update --file
+	vultr-cli load-balancer get 57539f6f-66a2-4580-936b-d0af934bce5d -o spec > lb.yaml
+	`
+
+	createLong = `Create a new Load Balancer with the desired settings
+
+The settings can also be read from a YAML or JSON spec file with --file, which
+is validated before calling the API.  Run 'load-balancer get <ID> -o spec' for
+an example of the format.`
 	createExample = `
 	# Full example
 	vultr-cli load-balancer create --region="lax" --balancing-algorithm="roundrobin" --label="Example Load Balancer" \
@@ -52,8 +67,56 @@ var (
 	#Full example with attached VPC
 	vultr-cli load-balancer create --region="lax"  --label="Example Load Balancer with VPC" \
 		--vpc="e951822b-10b2-4c5e-b333-bf38033e7175" --balancing-algorithm="leastconn"
+
+	# Create from a YAML or JSON spec file
+	vultr-cli load-balancer create --file lb.yaml
+	`
+	sslCheckLong = `Connect to the load balancer and check the expiry of the certificate it serves.
+
+The API does not return the installed certificate, so it is retrieved through a
+TLS handshake with the load balancer IPv4 address.  A warning is written to
+stderr when the certificate expires within the number of days given by --days.`
+	sslCheckExample = `
+	# Warn when the certificate expires within 30 days
+	vultr-cli load-balancer ssl check 57539f6f-66a2-4580-936b-d0af934bce5d
+
+	# Warn when the certificate expires within 14 days, sending the server name
+	vultr-cli load-balancer ssl check 57539f6f-66a2-4580-936b-d0af934bce5d --days 14 --server-name www.example.com
+	`
+
+	backendLong = `Manage the instances the load balancer forwards traffic to, one at a time.
+
+The health of each backend is derived from the instance status, power status
+and server status since the API does not expose the result of the load
+balancer health checks.`
+	backendExample = `
+	# List the backends and their health
+	vultr-cli load-balancer backend list 57539f6f-66a2-4580-936b-d0af934bce5d
+
+	# Rolling deploy: drain a node, upgrade it and add it back
+	vultr-cli load-balancer backend drain 57539f6f-66a2-4580-936b-d0af934bce5d web-1
+	vultr-cli load-balancer backend add 57539f6f-66a2-4580-936b-d0af934bce5d web-1 --wait
+	`
+	backendDrainLong = `Drain an instance from the load balancer backends so it can be taken down without
+cutting off requests in flight.
+
+The API has no connection draining of its own, so the instance is removed from the
+backends, which stops new connections from being sent to it once the load balancer
+is active again.  The connections it already has are left to finish during the
+--grace-period before the command returns.  Set the grace period to at least the
+longest request the instance serves.`
+	backendDrainExample = `
+	# Drain a backend, leaving its connections 30 seconds to finish
+	vultr-cli load-balancer backend drain 57539f6f-66a2-4580-936b-d0af934bce5d web-1
+
+	# Leave long running requests two minutes to finish
+	vultr-cli load-balancer backend drain 57539f6f-66a2-4580-936b-d0af934bce5d web-1 --grace-period 2m
 	`
-	updateLong    = `Update a Load Balancer with the desired settings`
+
+	updateLong = `Update a Load Balancer with the desired settings
+
+The settings can also be read from a YAML or JSON spec file with --file.  Only
+the fields present in the spec are updated.`
 	updateExample = `
 	# Full example
 	vultr-cli load-balancer update 57539f6f-66a2-4580-936b-d0af934bce5d --label="Updated Load Balancer Label" \
@@ -64,6 +127,9 @@ var (
 
 	#Full example with attached VPC
 	vultr-cli load-balancer update 57539f6f-66a2-4580-936b-d0af934bce5d --vpc="bff36707-977e-4357-8f30-bef3339155cc"
+
+	# Update from a YAML or JSON spec file
+	vultr-cli load-balancer update 57539f6f-66a2-4580-936b-d0af934bce5d --file lb.yaml
 	`
 )
 
@@ -76,6 +142,15 @@ const (
 	loadBalancerDefaultPort               = 80
 	loadBalancerDefaultFrontendPort       = 80
 	loadBalancerDefaultBackendPort        = 80
+
+	backendDefaultTimeout     = 5 * time.Minute
+	backendDefaultGracePeriod = 30 * time.Second
+	backendPollInterval       = 5 * time.Second
+	lbStatusActive            = "active"
+
+	backendHealthHealthy = "healthy"
+	backendHealthMissing = "missing"
+	backendHealthStopped = "stopped"
 )
 
 // NewCmdLoadBalancer provides the CLI command for load balancers
@@ -146,6 +221,8 @@ func NewCmdLoadBalancer(base *cli.Base) *cobra.Command { //nolint:funlen,gocyclo
 		Use:     "get <Load Balancer ID>",
 		Short:   "Retrieve a load balancer",
 		Aliases: []string{"g"},
+		Long:    getLong,
+		Example: getExample,
 		Args: func(cmd *cobra.Command, args []string) error {
 			if len(args) < 1 {
 				return errors.New("please provide a load balancer ID")
@@ -158,6 +235,16 @@ func NewCmdLoadBalancer(base *cli.Base) *cobra.Command { //nolint:funlen,gocyclo
 				return fmt.Errorf("error getting load balancer : %v", err)
 			}
 
+			if strings.ToLower(o.Base.Printer.Output) == outputSpec {
+				spec, errSp := NewSpecFromLoadBalancer(lb).YAML()
+				if errSp != nil {
+					return errSp
+				}
+
+				fmt.Fprint(os.Stdout, string(spec))
+				return nil
+			}
+
 			o.Base.Printer.Display(&LBPrinter{LB: lb}, nil)
 
 			return nil
@@ -172,6 +259,32 @@ func NewCmdLoadBalancer(base *cli.Base) *cobra.Command { //nolint:funlen,gocyclo
 		Long:    createLong,
 		Example: createExample,
 		RunE: func(cmd *cobra.Command, args []string) error {
+			file, errFi := cmd.Flags().GetString("file")
+			if errFi != nil {
+				return fmt.Errorf("error parsing flag 'file' for load balancer create : %v", errFi)
+			}
+
+			if file != "" {
+				if err := utils.FileOnly(cmd); err != nil {
+					return err
+				}
+
+				req, err := specRequest(file, true)
+				if err != nil {
+					return err
+				}
+				o.CreateReq = req
+
+				lb, err := o.create()
+				if err != nil {
+					return fmt.Errorf("error creating load balancer : %v", err)
+				}
+
+				o.Base.Printer.Display(&LBPrinter{LB: lb}, nil)
+
+				return nil
+			}
+
 			region, errRg := cmd.Flags().GetString("region")
 			if errRg != nil {
 				return fmt.Errorf("error parsing flag 'region' for load balancer create : %v", errRg)
@@ -435,10 +548,8 @@ func NewCmdLoadBalancer(base *cli.Base) *cobra.Command { //nolint:funlen,gocyclo
 	}
 
 	create.Flags().StringP("region", "r", "", "region id you wish to have the load balancer created in")
-	if err := create.MarkFlagRequired("region"); err != nil {
-		fmt.Printf("error marking load-balancer create 'region' flag required: %v", err)
-		os.Exit(1)
-	}
+	create.Flags().String("file", "", "(optional) Path to a YAML or JSON spec file with the load balancer settings")
+	create.MarkFlagsOneRequired("region", "file")
 
 	create.Flags().StringP(
 		"balancing-algorithm",
@@ -562,6 +673,31 @@ When not provided, load balancer defaults to public network.`,
 			return nil
 		},
 		RunE: func(cmd *cobra.Command, args []string) error {
+			file, errFi := cmd.Flags().GetString("file")
+			if errFi != nil {
+				return fmt.Errorf("error parsing flag 'file' for load balancer update : %v", errFi)
+			}
+
+			if file != "" {
+				if err := utils.FileOnly(cmd); err != nil {
+					return err
+				}
+
+				req, err := specRequest(file, false)
+				if err != nil {
+					return err
+				}
+				o.UpdateReq = req
+
+				if err := o.update(); err != nil {
+					return fmt.Errorf("error updating load balancer : %v", err)
+				}
+
+				o.Base.Printer.Display(printer.Info("Load balancer has been updated"), nil)
+
+				return nil
+			}
+
 			label, errLa := cmd.Flags().GetString("label")
 			if errLa != nil {
 				return fmt.Errorf("error parsing flag 'label' for load balancer update : %v", errLa)
@@ -845,6 +981,7 @@ When not provided, load balancer defaults to public network.`,
 	update.Flags().String("cookie-name", "", "(optional) the cookie name to make sticky.")
 
 	update.Flags().StringP("label", "l", "", "(optional) the label for your load balancer.")
+	update.Flags().String("file", "", "(optional) Path to a YAML or JSON spec file with the load balancer settings")
 	update.Flags().StringSliceP(
 		"instances",
 		"i",
@@ -944,6 +1081,25 @@ When not provided, load balancer defaults to public network.`,
 				return fmt.Errorf("error parsing flag 'base64' for load balancer ssl set-certificate: %v", errB64)
 			}
 
+			force, errFo := cmd.Flags().GetBool("force")
+			if errFo != nil {
+				return fmt.Errorf("error parsing flag 'force' for load balancer ssl set-certificate: %v", errFo)
+			}
+
+			certPEM, keyPEM, chainPEM := rawCertificate, rawPrivateKey, rawCertificateChain
+			if base64Encoded {
+				for _, v := range []*[]byte{&certPEM, &keyPEM, &chainPEM} {
+					if *v, err = decodeBase64PEM(*v); err != nil {
+						return err
+					}
+				}
+			}
+
+			cert, err := validateCertificate(certPEM, keyPEM, chainPEM, force)
+			if err != nil {
+				return err
+			}
+
 			o.UpdateReq = &govultr.LoadBalancerReq{
 				SSL: &govultr.SSL{},
 			}
@@ -962,7 +1118,7 @@ When not provided, load balancer defaults to public network.`,
 				return fmt.Errorf("error updating load balancer SSL certificate: %v", err)
 			}
 
-			o.Base.Printer.Display(printer.Info("Load balancer SSL certificate has been updated"), nil)
+			o.Base.Printer.Display(&CertificatePrinter{Certificate: cert}, nil)
 
 			return nil
 		},
@@ -982,6 +1138,85 @@ When not provided, load balancer defaults to public network.`,
 
 	sslSet.Flags().String("chain", "", "(optional) Path to SSL certificate chain")
 	sslSet.Flags().Bool("base64", false, "Indicates SSL values are Base64-encoded")
+	sslSet.Flags().Bool("force", false, "(optional) Upload the certificate even if it is expired or not yet valid")
+
+	// Check Load Balancer SSL
+	sslCheck := &cobra.Command{
+		Use:     "check <Load Balancer ID>",
+		Short:   "Check the expiry of the certificate served by a load balancer",
+		Long:    sslCheckLong,
+		Example: sslCheckExample,
+		Args: func(cmd *cobra.Command, args []string) error {
+			if len(args) < 1 {
+				return errors.New("please provide a load balancer ID")
+			}
+			return nil
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			days, errDa := cmd.Flags().GetInt("days")
+			if errDa != nil {
+				return fmt.Errorf("error parsing flag 'days' for load balancer ssl check : %v", errDa)
+			}
+
+			port, errPo := cmd.Flags().GetInt("port")
+			if errPo != nil {
+				return fmt.Errorf("error parsing flag 'port' for load balancer ssl check : %v", errPo)
+			}
+
+			serverName, errSn := cmd.Flags().GetString("server-name")
+			if errSn != nil {
+				return fmt.Errorf("error parsing flag 'server-name' for load balancer ssl check : %v", errSn)
+			}
+
+			lb, err := o.get()
+			if err != nil {
+				return fmt.Errorf("error getting load balancer : %v", err)
+			}
+
+			if port == 0 {
+				port = httpsPort(lb.ForwardingRules)
+			}
+
+			if serverName == "" && lb.AutoSSL != nil && lb.AutoSSL.DomainZone != "" {
+				serverName = lb.AutoSSL.DomainZone
+				if lb.AutoSSL.DomainSub != "" {
+					serverName = lb.AutoSSL.DomainSub + "." + serverName
+				}
+			}
+
+			leaf, chain, err := fetchCertificate(lb.IPV4, port, serverName)
+			if err != nil {
+				return fmt.Errorf("error retrieving load balancer certificate : %v", err)
+			}
+
+			cert := newCertificate(leaf, chain, days, time.Now())
+			if cert.Status != certStatusOK {
+				fmt.Fprintf(
+					os.Stderr,
+					"warning: the certificate is %s, it expires on %s (%d days)\n",
+					cert.Status,
+					cert.NotAfter.Format(time.RFC3339),
+					cert.DaysRemaining,
+				)
+			}
+
+			o.Base.Printer.Display(&CertificatePrinter{Certificate: cert}, nil)
+
+			return nil
+		},
+	}
+
+	sslCheck.Flags().Int(
+		"days",
+		certCheckDefaultDays,
+		"(optional) Warn when the certificate expires within the number of days",
+	)
+	sslCheck.Flags().Int("port", 0, "(optional) The port to connect to. Defaults to the first HTTPS forwarding rule")
+	sslCheck.Flags().String(
+		"server-name",
+		"",
+		"(optional) The server name sent to the load balancer. Defaults to the auto SSL domain",
+	)
 
 	// Remove Load Balancer SSL
 	sslDelete := &cobra.Command{
@@ -1072,6 +1307,7 @@ When not provided, load balancer defaults to public network.`,
 
 	ssl.AddCommand(
 		sslSet,
+		sslCheck,
 		sslDelete,
 		sslAutoSSLSet,
 		sslAutoSSLDelete,
@@ -1325,6 +1561,162 @@ When not provided, load balancer defaults to public network.`,
 		getFirewallRule,
 	)
 
+	// Backends
+	backend := &cobra.Command{
+		Use:     "backend",
+		Short:   "Commands to manage the backend instances of a load balancer",
+		Aliases: []string{"backends"},
+		Long:    backendLong,
+		Example: backendExample,
+	}
+
+	// List Backends
+	backendList := &cobra.Command{
+		Use:     "list <Load Balancer ID>",
+		Short:   "List the backend instances and their health",
+		Aliases: []string{"l"},
+		Args: func(cmd *cobra.Command, args []string) error {
+			if len(args) < 1 {
+				return errors.New("please provide a load balancer ID")
+			}
+			return nil
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			lb, err := o.get()
+			if err != nil {
+				return fmt.Errorf("error getting load balancer : %v", err)
+			}
+
+			o.Base.Printer.Display(&BackendsPrinter{Status: lb.Status, Backends: o.backends(lb.Instances)}, nil)
+
+			return nil
+		},
+	}
+
+	// Add Backend
+	backendAdd := &cobra.Command{
+		Use:   "add <Load Balancer ID> <Instance ID|Label>",
+		Short: "Add an instance to the load balancer backends",
+		Args: func(cmd *cobra.Command, args []string) error {
+			if len(args) < 2 {
+				return errors.New("please provide a load balancer ID and an instance ID or label")
+			}
+			return nil
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			lb, err := o.get()
+			if err != nil {
+				return fmt.Errorf("error getting load balancer : %v", err)
+			}
+
+			id, err := o.resolveInstance(args[1], nil)
+			if err != nil {
+				return err
+			}
+
+			if slices.Contains(lb.Instances, id) {
+				return fmt.Errorf("instance %s is already a backend of the load balancer", id)
+			}
+
+			instances := append(slices.Clone(lb.Instances), id)
+			lb, err = o.setBackends(cmd, instances)
+			if err != nil {
+				return fmt.Errorf("error adding load balancer backend : %v", err)
+			}
+
+			o.Base.Printer.Display(&BackendsPrinter{Status: lb.Status, Backends: o.backends(lb.Instances)}, nil)
+
+			return nil
+		},
+	}
+
+	backendAdd.Flags().Bool("wait", false, "(optional) Wait for the load balancer to apply the change")
+	backendAdd.Flags().Duration("timeout", backendDefaultTimeout, "(optional) The maximum time to wait")
+
+	// Remove Backend
+	backendRemove := &cobra.Command{
+		Use:     "remove <Load Balancer ID> <Instance ID|Label>",
+		Short:   "Remove an instance from the load balancer backends",
+		Aliases: []string{"rm"},
+		Args: func(cmd *cobra.Command, args []string) error {
+			if len(args) < 2 {
+				return errors.New("please provide a load balancer ID and an instance ID or label")
+			}
+			return nil
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			lb, instances, err := o.removeBackend(args[1])
+			if err != nil {
+				return err
+			}
+
+			lb, err = o.setBackends(cmd, instances)
+			if err != nil {
+				return fmt.Errorf("error removing load balancer backend : %v", err)
+			}
+
+			o.Base.Printer.Display(&BackendsPrinter{Status: lb.Status, Backends: o.backends(lb.Instances)}, nil)
+
+			return nil
+		},
+	}
+
+	backendRemove.Flags().Bool("wait", false, "(optional) Wait for the load balancer to apply the change")
+	backendRemove.Flags().Duration("timeout", backendDefaultTimeout, "(optional) The maximum time to wait")
+
+	// Drain Backend
+	backendDrain := &cobra.Command{
+		Use:     "drain <Load Balancer ID> <Instance ID|Label>",
+		Short:   "Remove an instance from the backends and let its connections finish",
+		Long:    backendDrainLong,
+		Example: backendDrainExample,
+		Args: func(cmd *cobra.Command, args []string) error {
+			if len(args) < 2 {
+				return errors.New("please provide a load balancer ID and an instance ID or label")
+			}
+			return nil
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			grace, errGr := cmd.Flags().GetDuration("grace-period")
+			if errGr != nil {
+				return fmt.Errorf("error parsing flag 'grace-period' for load-balancer backend drain : %v", errGr)
+			}
+
+			lb, instances, err := o.removeBackend(args[1])
+			if err != nil {
+				return err
+			}
+
+			lb, err = o.setBackends(cmd, instances)
+			if err != nil {
+				return fmt.Errorf("error draining load balancer backend : %v", err)
+			}
+
+			if grace > 0 {
+				fmt.Fprintf(os.Stderr, "waiting %s for the connections to %s to finish\n", grace, args[1])
+				time.Sleep(grace)
+			}
+
+			o.Base.Printer.Display(&BackendsPrinter{Status: lb.Status, Backends: o.backends(lb.Instances)}, nil)
+
+			return nil
+		},
+	}
+
+	backendDrain.Flags().Duration("timeout", backendDefaultTimeout, "(optional) The maximum time to wait")
+	backendDrain.Flags().Duration(
+		"grace-period",
+		backendDefaultGracePeriod,
+		"(optional) The time left for the connections of the instance to finish after it is removed",
+	)
+
+	backend.AddCommand(
+		backendList,
+		backendAdd,
+		backendRemove,
+		backendDrain,
+	)
+
 	cmd.AddCommand(
 		list,
 		get,
@@ -1334,6 +1726,7 @@ When not provided, load balancer defaults to public network.`,
 		forwarding,
 		firewall,
 		ssl,
+		backend,
 	)
 
 	return cmd
@@ -1502,3 +1895,163 @@ func formatForwardingRules(rules []string) ([]govultr.ForwardingRule, error) {
 
 	return formattedList, nil
 }
+
+// ======================================
+
+// backends retrieves the instances behind the load balancer and derives their
+// health from the instance state
+func (o *options) backends(ids []string) []Backend {
+	backends := make([]Backend, len(ids))
+	for i := range ids {
+		backends[i] = Backend{ID: ids[i]}
+
+		instance, _, err := o.Base.Client.Instance.Get(o.Base.Context, ids[i])
+		if err != nil {
+			backends[i].Health = backendHealthMissing
+			continue
+		}
+
+		backends[i].Label = instance.Label
+		backends[i].MainIP = instance.MainIP
+		backends[i].InternalIP = instance.InternalIP
+		backends[i].Status = instance.Status
+		backends[i].PowerStatus = instance.PowerStatus
+		backends[i].ServerStatus = instance.ServerStatus
+		backends[i].Health = instanceHealth(instance)
+	}
+
+	return backends
+}
+
+// resolveInstance returns the ID of the instance matching the ID or label.
+// When candidates is set, only those instance IDs are considered.
+func (o *options) resolveInstance(idOrLabel string, candidates []string) (string, error) {
+	if slices.Contains(candidates, idOrLabel) {
+		return idOrLabel, nil
+	}
+
+	instances, err := utils.ListAll(func(opts *govultr.ListOptions) ([]govultr.Instance, *govultr.Meta, error) {
+		opts.Label = idOrLabel
+		instances, meta, _, err := o.Base.Client.Instance.List(o.Base.Context, opts)
+		return instances, meta, err
+	})
+	if err != nil {
+		return "", fmt.Errorf("error listing instances : %v", err)
+	}
+
+	var matches []string
+	for i := range instances {
+		if candidates == nil || slices.Contains(candidates, instances[i].ID) {
+			matches = append(matches, instances[i].ID)
+		}
+	}
+
+	switch {
+	case len(matches) == 1:
+		return matches[0], nil
+	case len(matches) > 1:
+		return "", fmt.Errorf("label %s matches more than one instance : %s", idOrLabel, strings.Join(matches, ", "))
+	case candidates != nil:
+		return "", fmt.Errorf("instance %s is not a backend of the load balancer", idOrLabel)
+	}
+
+	// Not a label, so it must be an existing instance ID
+	instance, _, err := o.Base.Client.Instance.Get(o.Base.Context, idOrLabel)
+	if err != nil {
+		return "", fmt.Errorf("error getting instance %s : %v", idOrLabel, err)
+	}
+
+	return instance.ID, nil
+}
+
+// removeBackend returns the load balancer and its instance list without the
+// instance matching the ID or label
+func (o *options) removeBackend(idOrLabel string) (*govultr.LoadBalancer, []string, error) {
+	lb, err := o.get()
+	if err != nil {
+		return nil, nil, fmt.Errorf("error getting load balancer : %v", err)
+	}
+
+	id, err := o.resolveInstance(idOrLabel, lb.Instances)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	instances := slices.DeleteFunc(slices.Clone(lb.Instances), func(s string) bool { return s == id })
+	if len(instances) == 0 {
+		// The API ignores an empty instance list on update
+		return nil, nil, fmt.Errorf("instance %s is the last backend of the load balancer and cannot be removed", id)
+	}
+
+	return lb, instances, nil
+}
+
+// setBackends updates the load balancer instances and, when requested with
+// --wait or for the drain command, waits for the load balancer to be active
+func (o *options) setBackends(cmd *cobra.Command, instances []string) (*govultr.LoadBalancer, error) {
+	o.UpdateReq = &govultr.LoadBalancerReq{Instances: instances}
+	if err := o.update(); err != nil {
+		return nil, err
+	}
+
+	wait := cmd.Name() == "drain"
+	if cmd.Flags().Lookup("wait") != nil {
+		w, errWa := cmd.Flags().GetBool("wait")
+		if errWa != nil {
+			return nil, fmt.Errorf("error parsing flag 'wait' : %v", errWa)
+		}
+		wait = wait || w
+	}
+
+	timeout, errTi := cmd.Flags().GetDuration("timeout")
+	if errTi != nil {
+		return nil, fmt.Errorf("error parsing flag 'timeout' : %v", errTi)
+	}
+
+	if !wait {
+		return o.get()
+	}
+
+	return o.waitActive(timeout)
+}
+
+// waitActive polls the load balancer until it is active or the timeout expires
+func (o *options) waitActive(timeout time.Duration) (*govultr.LoadBalancer, error) {
+	deadline := time.Now().Add(timeout)
+	for {
+		// Give the load balancer time to pick up the change before the first check
+		time.Sleep(backendPollInterval)
+
+		lb, err := o.get()
+		if err != nil {
+			return nil, err
+		}
+
+		if lb.Status == lbStatusActive {
+			return lb, nil
+		}
+
+		if time.Now().After(deadline) {
+			return nil, fmt.Errorf(
+				"timed out after %s waiting for the load balancer to be active, status is %s",
+				timeout,
+				lb.Status,
+			)
+		}
+	}
+}
+
+// instanceHealth derives the backend health from the instance state.  The
+// first state preventing the instance from serving traffic is reported.
+func instanceHealth(i *govultr.Instance) string {
+	switch {
+	case i.Status != "active":
+		return i.Status
+	case i.PowerStatus != "running":
+		return backendHealthStopped
+	case i.ServerStatus != "ok":
+		return i.ServerStatus
+	default:
+		return backendHealthHealthy
+	}
+}
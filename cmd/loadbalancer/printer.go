@@ -2,6 +2,7 @@ package loadbalancer
 
 import (
 	"strconv"
+	"time"
 
 	"github.com/vultr/govultr/v3"
 	"github.com/vultr/vultr-cli/v3/cmd/printer"
@@ -517,3 +518,116 @@ func (f *FWRulePrinter) Data() [][]string {
 func (f *FWRulePrinter) Paging() [][]string {
 	return nil
 }
+
+// ======================================
+
+// Backend is an instance behind a load balancer
+type Backend struct {
+	ID           string `json:"id"`
+	Label        string `json:"label"`
+	MainIP       string `json:"main_ip"`
+	InternalIP   string `json:"internal_ip"`
+	Status       string `json:"status"`
+	PowerStatus  string `json:"power_status"`
+	ServerStatus string `json:"server_status"`
+	Health       string `json:"health"`
+}
+
+// BackendsPrinter ...
+type BackendsPrinter struct {
+	Status   string    `json:"status"`
+	Backends []Backend `json:"backends"`
+}
+
+// JSON ...
+func (b *BackendsPrinter) JSON() []byte {
+	return printer.MarshalObject(b, "json")
+}
+
+// YAML ...
+func (b *BackendsPrinter) YAML() []byte {
+	return printer.MarshalObject(b, "yaml")
+}
+
+// Columns ...
+func (b *BackendsPrinter) Columns() [][]string {
+	return [][]string{0: {
+		"ID",
+		"LABEL",
+		"MAIN IP",
+		"INTERNAL IP",
+		"STATUS",
+		"POWER STATUS",
+		"SERVER STATUS",
+		"HEALTH",
+	}}
+}
+
+// Data ...
+func (b *BackendsPrinter) Data() [][]string {
+	if len(b.Backends) == 0 {
+		return [][]string{0: {"---", "---", "---", "---", "---", "---", "---", "---"}}
+	}
+
+	var data [][]string
+	for i := range b.Backends {
+		data = append(data, []string{
+			b.Backends[i].ID,
+			b.Backends[i].Label,
+			b.Backends[i].MainIP,
+			b.Backends[i].InternalIP,
+			b.Backends[i].Status,
+			b.Backends[i].PowerStatus,
+			b.Backends[i].ServerStatus,
+			b.Backends[i].Health,
+		})
+	}
+
+	return data
+}
+
+// Paging ...
+func (b *BackendsPrinter) Paging() [][]string {
+	return nil
+}
+
+// ======================================
+
+// CertificatePrinter ...
+type CertificatePrinter struct {
+	Certificate *Certificate `json:"certificate"`
+}
+
+// JSON ...
+func (c *CertificatePrinter) JSON() []byte {
+	return printer.MarshalObject(c, "json")
+}
+
+// YAML ...
+func (c *CertificatePrinter) YAML() []byte {
+	return printer.MarshalObject(c, "yaml")
+}
+
+// Columns ...
+func (c *CertificatePrinter) Columns() [][]string {
+	return nil
+}
+
+// Data ...
+func (c *CertificatePrinter) Data() [][]string {
+	return [][]string{
+		{"SUBJECT", c.Certificate.Subject},
+		{"ISSUER", c.Certificate.Issuer},
+		{"SANS", printer.ArrayOfStringsToString(c.Certificate.SANs)},
+		{"NOT BEFORE", c.Certificate.NotBefore.Format(time.RFC3339)},
+		{"NOT AFTER", c.Certificate.NotAfter.Format(time.RFC3339)},
+		{"DAYS REMAINING", strconv.Itoa(c.Certificate.DaysRemaining)},
+		{"CHAIN", printer.ArrayOfStringsToString(c.Certificate.Chain)},
+		{"STATUS", c.Certificate.Status},
+	}
+}
+
+// Paging ...
+func (c *CertificatePrinter) Paging() [][]string {
+	return nil
+}
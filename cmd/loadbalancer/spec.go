@@ -0,0 +1,444 @@
+package loadbalancer
+
+import (
+	"bytes"
+	"fmt"
+	"net"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/vultr/govultr/v3"
+	"gopkg.in/yaml.v3"
+)
+
+const (
+	outputSpec string = "spec"
+
+	specMaxPort  int = 65535
+	specMaxNodes int = 99
+)
+
+// Spec is the structured document used to create or update a load balancer
+// with the --file flag.  Certificate files are resolved relative to the spec.
+type Spec struct {
+	Region             string               `yaml:"region,omitempty" json:"region,omitempty"`
+	Label              string               `yaml:"label,omitempty" json:"label,omitempty"`
+	BalancingAlgorithm string               `yaml:"balancing_algorithm,omitempty" json:"balancing_algorithm,omitempty"`
+	Nodes              int                  `yaml:"nodes,omitempty" json:"nodes,omitempty"`
+	Timeout            int                  `yaml:"timeout,omitempty" json:"timeout,omitempty"`
+	ProxyProtocol      *bool                `yaml:"proxy_protocol,omitempty" json:"proxy_protocol,omitempty"`
+	SSLRedirect        *bool                `yaml:"ssl_redirect,omitempty" json:"ssl_redirect,omitempty"`
+	HTTP2              *bool                `yaml:"http2,omitempty" json:"http2,omitempty"`
+	HTTP3              *bool                `yaml:"http3,omitempty" json:"http3,omitempty"`
+	VPC                *string              `yaml:"vpc,omitempty" json:"vpc,omitempty"`
+	Instances          []string             `yaml:"instances,omitempty" json:"instances,omitempty"`
+	GlobalRegions      []string             `yaml:"global_regions,omitempty" json:"global_regions,omitempty"`
+	HealthCheck        *SpecHealthCheck     `yaml:"health_check,omitempty" json:"health_check,omitempty"`
+	StickySessions     *SpecStickySessions  `yaml:"sticky_sessions,omitempty" json:"sticky_sessions,omitempty"`
+	ForwardingRules    []SpecForwardingRule `yaml:"forwarding_rules,omitempty" json:"forwarding_rules,omitempty"`
+	FirewallRules      []SpecFirewallRule   `yaml:"firewall_rules,omitempty" json:"firewall_rules,omitempty"`
+	SSL                *SpecSSL             `yaml:"ssl,omitempty" json:"ssl,omitempty"`
+	AutoSSL            *SpecAutoSSL         `yaml:"auto_ssl,omitempty" json:"auto_ssl,omitempty"`
+}
+
+// SpecHealthCheck is the health check section of a Spec
+type SpecHealthCheck struct {
+	Protocol           string `yaml:"protocol,omitempty" json:"protocol,omitempty"`
+	Port               int    `yaml:"port,omitempty" json:"port,omitempty"`
+	Path               string `yaml:"path,omitempty" json:"path,omitempty"`
+	CheckInterval      int    `yaml:"check_interval,omitempty" json:"check_interval,omitempty"`
+	ResponseTimeout    int    `yaml:"response_timeout,omitempty" json:"response_timeout,omitempty"`
+	UnhealthyThreshold int    `yaml:"unhealthy_threshold,omitempty" json:"unhealthy_threshold,omitempty"`
+	HealthyThreshold   int    `yaml:"healthy_threshold,omitempty" json:"healthy_threshold,omitempty"`
+}
+
+// SpecStickySessions is the sticky sessions section of a Spec
+type SpecStickySessions struct {
+	CookieName string `yaml:"cookie_name" json:"cookie_name"`
+}
+
+// SpecForwardingRule is a forwarding rule of a Spec
+type SpecForwardingRule struct {
+	FrontendProtocol string `yaml:"frontend_protocol" json:"frontend_protocol"`
+	FrontendPort     int    `yaml:"frontend_port" json:"frontend_port"`
+	BackendProtocol  string `yaml:"backend_protocol" json:"backend_protocol"`
+	BackendPort      int    `yaml:"backend_port" json:"backend_port"`
+}
+
+// SpecFirewallRule is a firewall rule of a Spec
+type SpecFirewallRule struct {
+	Port   int    `yaml:"port" json:"port"`
+	IPType string `yaml:"ip_type" json:"ip_type"`
+	Source string `yaml:"source" json:"source"`
+}
+
+// SpecSSL is the SSL section of a Spec.  The PEM contents are read from the
+// files, which take precedence over the inline values.
+type SpecSSL struct {
+	CertificateFile string `yaml:"certificate_file,omitempty" json:"certificate_file,omitempty"`
+	PrivateKeyFile  string `yaml:"private_key_file,omitempty" json:"private_key_file,omitempty"`
+	ChainFile       string `yaml:"chain_file,omitempty" json:"chain_file,omitempty"`
+	Certificate     string `yaml:"certificate,omitempty" json:"certificate,omitempty"`
+	PrivateKey      string `yaml:"private_key,omitempty" json:"private_key,omitempty"`
+	Chain           string `yaml:"chain,omitempty" json:"chain,omitempty"`
+}
+
+// SpecAutoSSL is the auto SSL section of a Spec
+type SpecAutoSSL struct {
+	DomainZone string `yaml:"domain_zone" json:"domain_zone"`
+	DomainSub  string `yaml:"domain_sub,omitempty" json:"domain_sub,omitempty"`
+}
+
+// NewSpecFromFile reads in a YAML or JSON spec file.  Unknown fields are an
+// error to catch typos before calling the API.
+func NewSpecFromFile(path string) (*Spec, error) {
+	fd, err := os.ReadFile(filepath.Clean(path))
+	if err != nil {
+		return nil, fmt.Errorf("error reading load balancer spec file : %v", err)
+	}
+
+	dec := yaml.NewDecoder(bytes.NewReader(fd))
+	dec.KnownFields(true)
+
+	spec := &Spec{}
+	if err := dec.Decode(spec); err != nil {
+		return nil, fmt.Errorf("error parsing load balancer spec file : %v", err)
+	}
+
+	if err := spec.resolveFiles(filepath.Dir(path)); err != nil {
+		return nil, err
+	}
+
+	return spec, nil
+}
+
+// NewSpecFromLoadBalancer builds a spec from an existing load balancer.  The
+// SSL certificate is not included since the private key is never returned.
+func NewSpecFromLoadBalancer(lb *govultr.LoadBalancer) *Spec {
+	spec := &Spec{
+		Region:        lb.Region,
+		Label:         lb.Label,
+		Nodes:         lb.Nodes,
+		HTTP2:         lb.HTTP2,
+		HTTP3:         lb.HTTP3,
+		Instances:     lb.Instances,
+		GlobalRegions: lb.GlobalRegions,
+	}
+
+	if lb.GenericInfo != nil {
+		spec.BalancingAlgorithm = lb.GenericInfo.BalancingAlgorithm
+		spec.Timeout = lb.GenericInfo.Timeout
+		spec.ProxyProtocol = lb.GenericInfo.ProxyProtocol
+		spec.SSLRedirect = lb.GenericInfo.SSLRedirect
+
+		if lb.GenericInfo.VPC != "" {
+			spec.VPC = govultr.StringToStringPtr(lb.GenericInfo.VPC)
+		}
+
+		if lb.GenericInfo.StickySessions != nil && lb.GenericInfo.StickySessions.CookieName != "" {
+			spec.StickySessions = &SpecStickySessions{CookieName: lb.GenericInfo.StickySessions.CookieName}
+		}
+	}
+
+	if lb.HealthCheck != nil {
+		spec.HealthCheck = &SpecHealthCheck{
+			Protocol:           lb.HealthCheck.Protocol,
+			Port:               lb.HealthCheck.Port,
+			Path:               lb.HealthCheck.Path,
+			CheckInterval:      lb.HealthCheck.CheckInterval,
+			ResponseTimeout:    lb.HealthCheck.ResponseTimeout,
+			UnhealthyThreshold: lb.HealthCheck.UnhealthyThreshold,
+			HealthyThreshold:   lb.HealthCheck.HealthyThreshold,
+		}
+	}
+
+	for i := range lb.ForwardingRules {
+		spec.ForwardingRules = append(spec.ForwardingRules, SpecForwardingRule{
+			FrontendProtocol: lb.ForwardingRules[i].FrontendProtocol,
+			FrontendPort:     lb.ForwardingRules[i].FrontendPort,
+			BackendProtocol:  lb.ForwardingRules[i].BackendProtocol,
+			BackendPort:      lb.ForwardingRules[i].BackendPort,
+		})
+	}
+
+	for i := range lb.FirewallRules {
+		spec.FirewallRules = append(spec.FirewallRules, SpecFirewallRule{
+			Port:   lb.FirewallRules[i].Port,
+			IPType: lb.FirewallRules[i].IPType,
+			Source: lb.FirewallRules[i].Source,
+		})
+	}
+
+	if lb.AutoSSL != nil && lb.AutoSSL.DomainZone != "" {
+		spec.AutoSSL = &SpecAutoSSL{DomainZone: lb.AutoSSL.DomainZone, DomainSub: lb.AutoSSL.DomainSub}
+	}
+
+	return spec
+}
+
+// YAML returns the spec as a YAML document
+func (s *Spec) YAML() ([]byte, error) {
+	var b bytes.Buffer
+	enc := yaml.NewEncoder(&b)
+	enc.SetIndent(2) //nolint:mnd
+
+	if err := enc.Encode(s); err != nil {
+		return nil, fmt.Errorf("error marshaling load balancer spec : %v", err)
+	}
+
+	return b.Bytes(), nil
+}
+
+// resolveFiles reads the SSL certificate files relative to the spec directory
+func (s *Spec) resolveFiles(dir string) error {
+	if s.SSL == nil {
+		return nil
+	}
+
+	files := []struct {
+		path  string
+		value *string
+	}{
+		{s.SSL.CertificateFile, &s.SSL.Certificate},
+		{s.SSL.PrivateKeyFile, &s.SSL.PrivateKey},
+		{s.SSL.ChainFile, &s.SSL.Chain},
+	}
+
+	for i := range files {
+		if files[i].path == "" {
+			continue
+		}
+
+		path := files[i].path
+		if !filepath.IsAbs(path) {
+			path = filepath.Join(dir, path)
+		}
+
+		fd, err := os.ReadFile(filepath.Clean(path))
+		if err != nil {
+			return fmt.Errorf("error reading load balancer spec SSL file : %v", err)
+		}
+		*files[i].value = string(fd)
+	}
+
+	return nil
+}
+
+// Validate checks the spec locally before calling the API.  The create checks
+// also require the fields which are only mandatory on creation.
+func (s *Spec) Validate(create bool) error { //nolint:gocyclo
+	var errs []string
+	addErr := func(format string, a ...interface{}) {
+		errs = append(errs, fmt.Sprintf(format, a...))
+	}
+
+	if create && s.Region == "" {
+		addErr("region is required")
+	}
+
+	if s.BalancingAlgorithm != "" && s.BalancingAlgorithm != "roundrobin" && s.BalancingAlgorithm != "leastconn" {
+		addErr("balancing_algorithm must be roundrobin or leastconn")
+	}
+
+	if s.Nodes != 0 && (s.Nodes < 1 || s.Nodes > specMaxNodes || s.Nodes%2 == 0) {
+		addErr("nodes must be an odd number between 1 and %d", specMaxNodes)
+	}
+
+	if s.Timeout < 0 {
+		addErr("timeout must be positive")
+	}
+
+	if s.HTTP3 != nil && *s.HTTP3 && (s.HTTP2 == nil || !*s.HTTP2) {
+		addErr("http3 requires http2 to be enabled")
+	}
+
+	if s.HealthCheck != nil {
+		if s.HealthCheck.Protocol != "" && !validProtocol(s.HealthCheck.Protocol) {
+			addErr("health_check.protocol must be http, https or tcp")
+		}
+
+		if s.HealthCheck.Port != 0 && !validPort(s.HealthCheck.Port) {
+			addErr("health_check.port must be between 1 and %d", specMaxPort)
+		}
+
+		if s.HealthCheck.CheckInterval < 0 || s.HealthCheck.ResponseTimeout < 0 ||
+			s.HealthCheck.UnhealthyThreshold < 0 || s.HealthCheck.HealthyThreshold < 0 {
+			addErr("health_check intervals, timeouts and thresholds must be positive")
+		}
+	}
+
+	if s.StickySessions != nil && s.StickySessions.CookieName == "" {
+		addErr("sticky_sessions.cookie_name is required")
+	}
+
+	hasHTTPS := false
+	seen := map[int]bool{}
+	for i := range s.ForwardingRules {
+		r := &s.ForwardingRules[i]
+		if !validProtocol(r.FrontendProtocol) || !validProtocol(r.BackendProtocol) {
+			addErr("forwarding_rules[%d] protocols must be http, https or tcp", i)
+		}
+
+		if !validPort(r.FrontendPort) || !validPort(r.BackendPort) {
+			addErr("forwarding_rules[%d] ports must be between 1 and %d", i, specMaxPort)
+		}
+
+		if seen[r.FrontendPort] {
+			addErr("forwarding_rules[%d] frontend_port %d is used by more than one rule", i, r.FrontendPort)
+		}
+		seen[r.FrontendPort] = true
+
+		if strings.EqualFold(r.FrontendProtocol, "https") {
+			hasHTTPS = true
+		}
+	}
+
+	for i := range s.FirewallRules {
+		r := &s.FirewallRules[i]
+		if !validPort(r.Port) {
+			addErr("firewall_rules[%d] port must be between 1 and %d", i, specMaxPort)
+		}
+
+		if r.IPType != "v4" && r.IPType != "v6" {
+			addErr("firewall_rules[%d] ip_type must be v4 or v6", i)
+		}
+
+		if r.Source != "cloudflare" {
+			if _, _, err := net.ParseCIDR(r.Source); err != nil {
+				addErr("firewall_rules[%d] source must be a CIDR subnet or cloudflare", i)
+			}
+		}
+	}
+
+	if s.SSL != nil {
+		if s.AutoSSL != nil {
+			addErr("ssl and auto_ssl cannot both be set")
+		}
+
+		if s.SSL.Certificate == "" || s.SSL.PrivateKey == "" {
+			addErr("ssl requires a certificate and a private key")
+		}
+	}
+
+	if s.AutoSSL != nil && s.AutoSSL.DomainZone == "" {
+		addErr("auto_ssl.domain_zone is required")
+	}
+
+	if create && hasHTTPS && s.SSL == nil && s.AutoSSL == nil {
+		addErr("an https forwarding rule requires ssl or auto_ssl")
+	}
+
+	if create && s.SSLRedirect != nil && *s.SSLRedirect && !hasHTTPS {
+		addErr("ssl_redirect requires an https forwarding rule")
+	}
+
+	if len(errs) > 0 {
+		return fmt.Errorf("invalid load balancer spec :\n  %s", strings.Join(errs, "\n  "))
+	}
+
+	return nil
+}
+
+// Request converts the spec into the API request
+func (s *Spec) Request() *govultr.LoadBalancerReq {
+	req := &govultr.LoadBalancerReq{
+		Region:             s.Region,
+		Label:              s.Label,
+		Instances:          s.Instances,
+		Nodes:              s.Nodes,
+		SSLRedirect:        s.SSLRedirect,
+		HTTP2:              s.HTTP2,
+		HTTP3:              s.HTTP3,
+		ProxyProtocol:      s.ProxyProtocol,
+		BalancingAlgorithm: s.BalancingAlgorithm,
+		Timeout:            s.Timeout,
+		VPC:                s.VPC,
+		GlobalRegions:      s.GlobalRegions,
+	}
+
+	if s.HealthCheck != nil {
+		req.HealthCheck = &govultr.HealthCheck{
+			Protocol:           s.HealthCheck.Protocol,
+			Port:               s.HealthCheck.Port,
+			Path:               s.HealthCheck.Path,
+			CheckInterval:      s.HealthCheck.CheckInterval,
+			ResponseTimeout:    s.HealthCheck.ResponseTimeout,
+			UnhealthyThreshold: s.HealthCheck.UnhealthyThreshold,
+			HealthyThreshold:   s.HealthCheck.HealthyThreshold,
+		}
+	}
+
+	if s.StickySessions != nil {
+		req.StickySessions = &govultr.StickySessions{CookieName: s.StickySessions.CookieName}
+	}
+
+	for i := range s.ForwardingRules {
+		req.ForwardingRules = append(req.ForwardingRules, govultr.ForwardingRule{
+			FrontendProtocol: s.ForwardingRules[i].FrontendProtocol,
+			FrontendPort:     s.ForwardingRules[i].FrontendPort,
+			BackendProtocol:  s.ForwardingRules[i].BackendProtocol,
+			BackendPort:      s.ForwardingRules[i].BackendPort,
+		})
+	}
+
+	for i := range s.FirewallRules {
+		req.FirewallRules = append(req.FirewallRules, govultr.LBFirewallRule{
+			Port:   s.FirewallRules[i].Port,
+			IPType: s.FirewallRules[i].IPType,
+			Source: s.FirewallRules[i].Source,
+		})
+	}
+
+	if s.SSL != nil {
+		req.SSL = &govultr.SSL{
+			Certificate: s.SSL.Certificate,
+			PrivateKey:  s.SSL.PrivateKey,
+			Chain:       s.SSL.Chain,
+		}
+	}
+
+	if s.AutoSSL != nil {
+		req.AutoSSL = &govultr.AutoSSL{DomainZone: s.AutoSSL.DomainZone, DomainSub: s.AutoSSL.DomainSub}
+	}
+
+	return req
+}
+
+// specRequest reads, validates and converts a spec file into an API request.
+// The SSL certificate is checked the same way as with ssl set-certificate.
+func specRequest(path string, create bool) (*govultr.LoadBalancerReq, error) {
+	spec, err := NewSpecFromFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := spec.Validate(create); err != nil {
+		return nil, err
+	}
+
+	if spec.SSL != nil {
+		if _, err := validateCertificate(
+			[]byte(spec.SSL.Certificate),
+			[]byte(spec.SSL.PrivateKey),
+			[]byte(spec.SSL.Chain),
+			false,
+		); err != nil {
+			return nil, err
+		}
+	}
+
+	return spec.Request(), nil
+}
+
+func validProtocol(p string) bool {
+	switch strings.ToLower(p) {
+	case "http", "https", "tcp":
+		return true
+	}
+	return false
+}
+
+func validPort(p int) bool {
+	return p > 0 && p <= specMaxPort
+}
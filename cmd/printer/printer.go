@@ -82,6 +82,26 @@ func (o *Output) Display(r ResourceOutput, err error) {
 	}
 }
 
+// Print displays the ResourceOutput data in the output format like Display
+// but returns after JSON and YAML output rather than exiting, so that commands
+// can set their own exit status afterwards
+func (o *Output) Print(r ResourceOutput) {
+	defer o.flush()
+
+	switch strings.ToLower(o.Output) {
+	case "json":
+		o.displayNonText(r.JSON())
+	case "yaml":
+		o.displayNonText(r.YAML())
+	default:
+		o.display(r.Columns())
+		o.display(r.Data())
+		if r.Paging() != nil {
+			o.display(r.Paging())
+		}
+	}
+}
+
 func (o *Output) display(d [][]string) {
 	for n := range d {
 		for i := range d[n] {
@@ -2,6 +2,7 @@
 package cmd
 
 import (
+	"errors"
 	"fmt"
 	"os"
 	"strings"
@@ -16,11 +17,13 @@ import (
 	"github.com/vultr/vultr-cli/v3/cmd/blockstorage"
 	"github.com/vultr/vultr-cli/v3/cmd/cdn"
 	"github.com/vultr/vultr-cli/v3/cmd/containerregistry"
+	"github.com/vultr/vultr-cli/v3/cmd/cost"
 	"github.com/vultr/vultr-cli/v3/cmd/database"
 	"github.com/vultr/vultr-cli/v3/cmd/dns"
 	"github.com/vultr/vultr-cli/v3/cmd/firewall"
 	"github.com/vultr/vultr-cli/v3/cmd/inference"
 	"github.com/vultr/vultr-cli/v3/cmd/instance"
+	"github.com/vultr/vultr-cli/v3/cmd/inventory"
 	"github.com/vultr/vultr-cli/v3/cmd/iso"
 	"github.com/vultr/vultr-cli/v3/cmd/kubernetes"
 	"github.com/vultr/vultr-cli/v3/cmd/loadbalancer"
@@ -34,7 +37,9 @@ import (
 	"github.com/vultr/vultr-cli/v3/cmd/script"
 	"github.com/vultr/vultr-cli/v3/cmd/snapshot"
 	"github.com/vultr/vultr-cli/v3/cmd/sshkeys"
+	"github.com/vultr/vultr-cli/v3/cmd/summary"
 	"github.com/vultr/vultr-cli/v3/cmd/users"
+	"github.com/vultr/vultr-cli/v3/cmd/utils"
 	"github.com/vultr/vultr-cli/v3/cmd/version"
 	"github.com/vultr/vultr-cli/v3/cmd/vpc"
 	"github.com/vultr/vultr-cli/v3/cmd/vpc2"
@@ -58,6 +63,10 @@ var rootCmd = &cobra.Command{
 // This is called by main.main(). It only needs to happen once to the rootCmd.
 func Execute() {
 	if err := rootCmd.Execute(); err != nil {
+		var exitErr *utils.ExitError
+		if errors.As(err, &exitErr) {
+			os.Exit(exitErr.Code)
+		}
 		os.Exit(1)
 	}
 }
@@ -82,6 +91,11 @@ func init() {
 	}
 	viper.SetEnvKeyReplacer(strings.NewReplacer("-", "_"))
 
+	// read in the env var to skip confirmation prompts
+	if err := viper.BindEnv("assume-yes", "VULTR_CLI_ASSUME_YES"); err != nil {
+		fmt.Printf("error binding VULTR_CLI_ASSUME_YES env var: %v", err)
+	}
+
 	// init the config file with viper just before commands are executed
 	cobra.OnInitialize(initConfig)
 
@@ -95,11 +109,13 @@ func init() {
 		billing.NewCmdBilling(base),
 		blockstorage.NewCmdBlockStorage(base),
 		containerregistry.NewCmdContainerRegistry(base),
+		cost.NewCmdCost(base),
 		cdn.NewCmdCDN(base),
 		database.NewCmdDatabase(base),
 		dns.NewCmdDNS(base),
 		firewall.NewCmdFirewall(base),
 		inference.NewCmdInference(base),
+		inventory.NewCmdInventory(base),
 		iso.NewCmdISO(base),
 		kubernetes.NewCmdKubernetes(base),
 		loadbalancer.NewCmdLoadBalancer(base),
@@ -114,6 +130,7 @@ func init() {
 		instance.NewCmdInstance(base),
 		snapshot.NewCmdSnapshot(base),
 		sshkeys.NewCmdSSHKey(base),
+		summary.NewCmdSummary(base),
 		users.NewCmdUser(base),
 		version.NewCmdVersion(base),
 		vpc.NewCmdVPC(base),
@@ -95,3 +95,75 @@ func (s *ScriptPrinter) Data() [][]string {
 func (s *ScriptPrinter) Paging() [][]string {
 	return nil
 }
+
+// ======================================
+
+// ScriptSyncResult is the outcome of syncing a single startup script
+type ScriptSyncResult struct {
+	Action string `json:"action"`
+	ID     string `json:"id"`
+	Name   string `json:"name"`
+	Type   string `json:"type"`
+}
+
+// ScriptSyncPrinter ...
+type ScriptSyncPrinter struct {
+	Results []ScriptSyncResult `json:"results"`
+	DryRun  bool               `json:"dry_run"`
+}
+
+// JSON ...
+func (s *ScriptSyncPrinter) JSON() []byte {
+	return printer.MarshalObject(s, "json")
+}
+
+// YAML ...
+func (s *ScriptSyncPrinter) YAML() []byte {
+	return printer.MarshalObject(s, "yaml")
+}
+
+// Columns ...
+func (s *ScriptSyncPrinter) Columns() [][]string {
+	return [][]string{0: {
+		"ACTION",
+		"ID",
+		"NAME",
+		"TYPE",
+	}}
+}
+
+// Data ...
+func (s *ScriptSyncPrinter) Data() [][]string {
+	if len(s.Results) == 0 {
+		return [][]string{0: {"---", "---", "---", "---"}}
+	}
+
+	var data [][]string
+	for i := range s.Results {
+		id := s.Results[i].ID
+		if id == "" {
+			id = "---"
+		}
+
+		data = append(data, []string{
+			s.Results[i].Action,
+			id,
+			s.Results[i].Name,
+			s.Results[i].Type,
+		})
+	}
+
+	return data
+}
+
+// Paging ...
+func (s *ScriptSyncPrinter) Paging() [][]string {
+	if !s.DryRun {
+		return nil
+	}
+
+	return [][]string{
+		0: {"======================================"},
+		1: {"DRY RUN: no changes have been made"},
+	}
+}
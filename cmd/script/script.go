@@ -2,11 +2,14 @@
 package script
 
 import (
+	"bytes"
 	"encoding/base64"
 	"errors"
 	"fmt"
 	"os"
 	"path/filepath"
+	"sort"
+	"strings"
 
 	"github.com/spf13/cobra"
 	"github.com/vultr/govultr/v3"
@@ -15,6 +18,55 @@ import (
 	"github.com/vultr/vultr-cli/v3/pkg/cli"
 )
 
+const (
+	scriptTypeBoot string = "boot"
+	scriptTypePXE  string = "pxe"
+
+	syncActionCreate    string = "create"
+	syncActionUpdate    string = "update"
+	syncActionDelete    string = "delete"
+	syncActionUnchanged string = "unchanged"
+	syncActionUnmanaged string = "unmanaged"
+)
+
+var (
+	syncLong = `Synchronize the startup scripts on your account with the files in a local
+directory.
+
+Each regular file in the directory becomes a startup script named after the
+file, without its extension.  Files with a .ipxe or .pxe extension, or which
+start with #!ipxe, are created as PXE scripts and all others as boot scripts.
+Existing scripts with the same name are updated when their content or type has
+changed.  Scripts on the account which have no matching file are left alone
+unless --delete is provided.`
+	syncExample = `
+	# Full example
+	vultr-cli script sync ./scripts/
+
+	# Preview the changes without applying them
+	vultr-cli script sync ./scripts/ --dry-run
+
+	# Also delete the scripts which have no matching file
+	vultr-cli script sync ./scripts/ --delete
+	`
+
+	updateLong = `Update a startup script on your account.  Only the given fields are updated.
+
+The contents given with --script are sent as is and must already be base64
+encoded, unless --encode is provided to encode them as on create.  The contents
+read from --script-file are always base64 encoded.`
+	updateExample = `
+	# Full example
+	vultr-cli script update <Script ID> --script-file ./setup.sh
+
+	# Update the name only
+	vultr-cli script update <Script ID> --name web-setup
+
+	# Update the contents from plain text
+	vultr-cli script update <Script ID> --script "#!/bin/bash" --encode
+	`
+)
+
 // NewCmdScript provides the CLI command for startup script functions
 func NewCmdScript(base *cli.Base) *cobra.Command { //nolint:gocyclo
 	o := &options{Base: base}
@@ -73,11 +125,21 @@ func NewCmdScript(base *cli.Base) *cobra.Command { //nolint:gocyclo
 			return nil
 		},
 		RunE: func(cmd *cobra.Command, args []string) error {
+			raw, errRa := cmd.Flags().GetBool("raw")
+			if errRa != nil {
+				return fmt.Errorf("error parsing flag 'raw' for script get : %v", errRa)
+			}
+
 			script, err := o.get()
 			if err != nil {
 				return fmt.Errorf("error getting startup script : %v", err)
 			}
 
+			if raw {
+				fmt.Fprintf(cmd.OutOrStdout(), "%s", decodeScript(script.Script))
+				return nil
+			}
+
 			data := &ScriptPrinter{Script: script}
 			o.Base.Printer.Display(data, nil)
 
@@ -85,6 +147,8 @@ func NewCmdScript(base *cli.Base) *cobra.Command { //nolint:gocyclo
 		},
 	}
 
+	get.Flags().Bool("raw", false, "Write only the decoded script contents to stdout.")
+
 	// Create
 	create := &cobra.Command{
 		Use:   "create",
@@ -159,8 +223,10 @@ func NewCmdScript(base *cli.Base) *cobra.Command { //nolint:gocyclo
 
 	// Update
 	update := &cobra.Command{
-		Use:   "update <Script ID>",
-		Short: "Update startup script",
+		Use:     "update <Script ID>",
+		Short:   "Update startup script",
+		Long:    updateLong,
+		Example: updateExample,
 		Args: func(cmd *cobra.Command, args []string) error {
 			if len(args) < 1 {
 				return errors.New("please provide a script ID")
@@ -178,15 +244,37 @@ func NewCmdScript(base *cli.Base) *cobra.Command { //nolint:gocyclo
 				return fmt.Errorf("error parsing flag 'script' for script update : %v", errSc)
 			}
 
+			scriptFile, errSf := cmd.Flags().GetString("script-file")
+			if errSf != nil {
+				return fmt.Errorf("error parsing flag 'script-file' for script update : %v", errSf)
+			}
+
 			sType, errST := cmd.Flags().GetString("type")
 			if errST != nil {
 				return fmt.Errorf("error parsing flag 'type' for script update : %v", errST)
 			}
 
+			encode, errEn := cmd.Flags().GetBool("encode")
+			if errEn != nil {
+				return fmt.Errorf("error parsing flag 'encode' for script update : %v", errEn)
+			}
+
 			o.ScriptReq = &govultr.StartupScriptReq{
 				Name:   name,
-				Script: script,
 				Type:   sType,
+				Script: script,
+			}
+
+			if script != "" && encode {
+				o.ScriptReq.Script = base64.StdEncoding.EncodeToString([]byte(script))
+			}
+
+			if scriptFile != "" {
+				fd, err := os.ReadFile(filepath.Clean(scriptFile))
+				if err != nil {
+					return fmt.Errorf("error reading script file: %v", err)
+				}
+				o.ScriptReq.Script = base64.StdEncoding.EncodeToString(fd)
 			}
 
 			if err := o.update(); err != nil {
@@ -199,29 +287,24 @@ func NewCmdScript(base *cli.Base) *cobra.Command { //nolint:gocyclo
 		},
 	}
 
-	update.Flags().StringP("name", "n", "", "Name of the startup script.")
-	update.Flags().StringP("script", "s", "", "Startup script contents.")
+	update.Flags().StringP("name", "n", "", "(optional) Name of the startup script.")
+	update.Flags().StringP(
+		"script",
+		"s",
+		"",
+		"(optional) Startup script contents, sent as is unless --encode is provided.",
+	)
+	update.Flags().StringP("script-file", "f", "", "(optional) File path to read in the startup script contents.")
+	update.Flags().Bool("encode", false, "(optional) Base64 encode the --script contents before they are sent.")
 	update.Flags().StringP(
 		"type",
 		"t",
 		"",
-		"Type of startup script. Possible values: 'boot', 'pxe'. Default is 'boot'.",
+		"(optional) Type of startup script. Possible values: 'boot', 'pxe'.",
 	)
-
-	if err := update.MarkFlagRequired("name"); err != nil {
-		fmt.Printf("error marking script update 'name' flag required: %v", err)
-		os.Exit(1)
-	}
-
-	if err := update.MarkFlagRequired("script"); err != nil {
-		fmt.Printf("error marking script update 'script' flag required: %v", err)
-		os.Exit(1)
-	}
-
-	if err := update.MarkFlagRequired("type"); err != nil {
-		fmt.Printf("error marking script update 'type' flag required: %v", err)
-		os.Exit(1)
-	}
+	update.MarkFlagsOneRequired("name", "script", "script-file", "type")
+	update.MarkFlagsMutuallyExclusive("script", "script-file")
+	update.MarkFlagsMutuallyExclusive("encode", "script-file")
 
 	// Delete
 	del := &cobra.Command{
@@ -245,20 +328,64 @@ func NewCmdScript(base *cli.Base) *cobra.Command { //nolint:gocyclo
 		},
 	}
 
+	// Sync
+	sync := &cobra.Command{
+		Use:     "sync <Directory>",
+		Short:   "Synchronize startup scripts from a local directory",
+		Long:    syncLong,
+		Example: syncExample,
+		Args: func(cmd *cobra.Command, args []string) error {
+			if len(args) < 1 {
+				return errors.New("please provide a directory")
+			}
+			return nil
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			deleteUnmanaged, errDe := cmd.Flags().GetBool("delete")
+			if errDe != nil {
+				return fmt.Errorf("error parsing flag 'delete' for script sync : %v", errDe)
+			}
+
+			dryRun, errDr := cmd.Flags().GetBool("dry-run")
+			if errDr != nil {
+				return fmt.Errorf("error parsing flag 'dry-run' for script sync : %v", errDr)
+			}
+
+			o.SyncDelete = deleteUnmanaged
+			o.SyncDryRun = dryRun
+
+			results, err := o.sync()
+			if err != nil {
+				return fmt.Errorf("error syncing startup scripts : %v", err)
+			}
+
+			data := &ScriptSyncPrinter{Results: results, DryRun: dryRun}
+			o.Base.Printer.Display(data, nil)
+
+			return nil
+		},
+	}
+
+	sync.Flags().Bool("delete", false, "(optional) Delete the startup scripts which have no matching file.")
+	sync.Flags().Bool("dry-run", false, "(optional) Display the changes without applying them.")
+
 	cmd.AddCommand(
 		list,
 		get,
 		create,
 		update,
 		del,
+		sync,
 	)
 
 	return cmd
 }
 
 type options struct {
-	Base      *cli.Base
-	ScriptReq *govultr.StartupScriptReq
+	Base       *cli.Base
+	ScriptReq  *govultr.StartupScriptReq
+	SyncDelete bool
+	SyncDryRun bool
 }
 
 func (o *options) list() ([]govultr.StartupScript, *govultr.Meta, error) {
@@ -283,3 +410,157 @@ func (o *options) update() error {
 func (o *options) del() error {
 	return o.Base.Client.StartupScript.Delete(o.Base.Context, o.Base.Args[0])
 }
+
+func (o *options) listAll() ([]govultr.StartupScript, error) {
+	return utils.ListAll(func(opts *govultr.ListOptions) ([]govultr.StartupScript, *govultr.Meta, error) {
+		scripts, meta, _, err := o.Base.Client.StartupScript.List(o.Base.Context, opts)
+		return scripts, meta, err
+	})
+}
+
+// localScript is a startup script read in from a file for sync
+type localScript struct {
+	Name   string
+	Type   string
+	Script []byte
+}
+
+// readScriptDir reads the regular, non-hidden files in the directory as startup
+// scripts, named after the file without its extension
+func readScriptDir(dir string) ([]localScript, error) {
+	entries, err := os.ReadDir(filepath.Clean(dir))
+	if err != nil {
+		return nil, fmt.Errorf("error reading script directory : %v", err)
+	}
+
+	var scripts []localScript
+	files := map[string]string{}
+	for i := range entries {
+		if !entries[i].Type().IsRegular() || strings.HasPrefix(entries[i].Name(), ".") {
+			continue
+		}
+
+		fileName := entries[i].Name()
+		ext := filepath.Ext(fileName)
+		name := strings.TrimSuffix(fileName, ext)
+
+		if prev, ok := files[name]; ok {
+			return nil, fmt.Errorf("files %q and %q would both sync to the script named %q", prev, fileName, name)
+		}
+		files[name] = fileName
+
+		fd, err := os.ReadFile(filepath.Join(filepath.Clean(dir), fileName))
+		if err != nil {
+			return nil, fmt.Errorf("error reading script file : %v", err)
+		}
+
+		sType := scriptTypeBoot
+		if ext == ".ipxe" || ext == ".pxe" || bytes.HasPrefix(fd, []byte("#!ipxe")) {
+			sType = scriptTypePXE
+		}
+
+		scripts = append(scripts, localScript{Name: name, Type: sType, Script: fd})
+	}
+
+	sort.Slice(scripts, func(i, j int) bool { return scripts[i].Name < scripts[j].Name })
+
+	return scripts, nil
+}
+
+// sync reconciles the account startup scripts with the files in the directory
+func (o *options) sync() ([]ScriptSyncResult, error) {
+	local, err := readScriptDir(o.Base.Args[0])
+	if err != nil {
+		return nil, err
+	}
+
+	remote, err := o.listAll()
+	if err != nil {
+		return nil, fmt.Errorf("error retrieving startup scripts : %v", err)
+	}
+
+	byName := map[string]govultr.StartupScript{}
+	for i := range remote {
+		if _, ok := byName[remote[i].Name]; ok {
+			return nil, fmt.Errorf("multiple startup scripts on the account are named %q", remote[i].Name)
+		}
+		byName[remote[i].Name] = remote[i]
+	}
+
+	var results []ScriptSyncResult
+	for i := range local {
+		req := &govultr.StartupScriptReq{
+			Name:   local[i].Name,
+			Type:   local[i].Type,
+			Script: base64.StdEncoding.EncodeToString(local[i].Script),
+		}
+
+		existing, ok := byName[local[i].Name]
+		if !ok {
+			result := ScriptSyncResult{Action: syncActionCreate, Name: req.Name, Type: req.Type}
+			if !o.SyncDryRun {
+				script, _, err := o.Base.Client.StartupScript.Create(o.Base.Context, req)
+				if err != nil {
+					return results, fmt.Errorf("error creating script %q : %v", req.Name, err)
+				}
+				result.ID = script.ID
+			}
+			results = append(results, result)
+			continue
+		}
+		delete(byName, local[i].Name)
+
+		current, _, err := o.Base.Client.StartupScript.Get(o.Base.Context, existing.ID)
+		if err != nil {
+			return results, fmt.Errorf("error retrieving script %q : %v", existing.Name, err)
+		}
+
+		result := ScriptSyncResult{Action: syncActionUnchanged, ID: existing.ID, Name: req.Name, Type: req.Type}
+		if current.Type != req.Type || !bytes.Equal(decodeScript(current.Script), local[i].Script) {
+			result.Action = syncActionUpdate
+			if !o.SyncDryRun {
+				if err := o.Base.Client.StartupScript.Update(o.Base.Context, existing.ID, req); err != nil {
+					return results, fmt.Errorf("error updating script %q : %v", req.Name, err)
+				}
+			}
+		}
+		results = append(results, result)
+	}
+
+	var unmanaged []govultr.StartupScript
+	for _, script := range byName {
+		unmanaged = append(unmanaged, script)
+	}
+	sort.Slice(unmanaged, func(i, j int) bool { return unmanaged[i].Name < unmanaged[j].Name })
+
+	for i := range unmanaged {
+		result := ScriptSyncResult{
+			Action: syncActionUnmanaged,
+			ID:     unmanaged[i].ID,
+			Name:   unmanaged[i].Name,
+			Type:   unmanaged[i].Type,
+		}
+
+		if o.SyncDelete {
+			result.Action = syncActionDelete
+			if !o.SyncDryRun {
+				if err := o.Base.Client.StartupScript.Delete(o.Base.Context, unmanaged[i].ID); err != nil {
+					return results, fmt.Errorf("error deleting script %q : %v", unmanaged[i].Name, err)
+				}
+			}
+		}
+		results = append(results, result)
+	}
+
+	return results, nil
+}
+
+// decodeScript returns the decoded script contents as returned by the API,
+// falling back to the contents as-is when they are not base64 encoded
+func decodeScript(script string) []byte {
+	decoded, err := base64.StdEncoding.DecodeString(script)
+	if err != nil {
+		return []byte(script)
+	}
+	return decoded
+}
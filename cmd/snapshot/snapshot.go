@@ -8,11 +8,28 @@ import (
 
 	"github.com/spf13/cobra"
 	"github.com/vultr/govultr/v3"
+	"github.com/vultr/vultr-cli/v3/cmd/bulk"
 	"github.com/vultr/vultr-cli/v3/cmd/printer"
 	"github.com/vultr/vultr-cli/v3/cmd/utils"
 	"github.com/vultr/vultr-cli/v3/pkg/cli"
 )
 
+var (
+	deleteExample = `
+	# Full example
+	vultr-cli snapshot delete 2126b7d9-5e2a-491e-8840-838aa6b5f294
+
+	# Delete every snapshot older than 30 days
+	vultr-cli snapshot delete --older-than="30d"
+
+	# Delete every snapshot with a specific description, without the confirmation prompt
+	vultr-cli snapshot delete --selector="description=nightly" --yes
+	`
+)
+
+// selectorKeys are the keys supported by the snapshot bulk --selector flag
+var selectorKeys = []string{"description", "status"}
+
 // NewCmdSnapshot provides the CLI command for snapshot functions
 func NewCmdSnapshot(base *cli.Base) *cobra.Command { //nolint:gocyclo
 	o := &options{Base: base}
@@ -165,13 +182,39 @@ func NewCmdSnapshot(base *cli.Base) *cobra.Command { //nolint:gocyclo
 		Use:     "delete <Snapshot ID>",
 		Short:   "Delete a snapshot",
 		Aliases: []string{"destroy"},
-		Args: func(cmd *cobra.Command, args []string) error {
-			if len(args) < 1 {
-				return errors.New("please provide a snapshot ID")
-			}
-			return nil
-		},
+		Example: deleteExample,
+		Args:    bulk.Args("please provide a snapshot ID"),
 		RunE: func(cmd *cobra.Command, args []string) error {
+			bo, errBu := bulk.GetFlags(cmd, selectorKeys)
+			if errBu != nil {
+				return fmt.Errorf("error parsing bulk flags for snapshot delete : %v", errBu)
+			}
+
+			if bo.Enabled() {
+				items, err := o.selectSnapshots(bo)
+				if err != nil {
+					return fmt.Errorf("error selecting snapshots : %v", err)
+				}
+
+				return bo.Execute(o.Base.Printer, "snapshot delete", items, func(id string) error {
+					return o.Base.Client.Snapshot.Delete(o.Base.Context, id)
+				})
+			}
+
+			if err := utils.ConfirmDelete(cmd, func() (*utils.Resource, error) {
+				snapshot, err := o.get()
+				if err != nil {
+					return nil, err
+				}
+				return &utils.Resource{
+					Kind:  "snapshot",
+					ID:    snapshot.ID,
+					Label: snapshot.Description,
+				}, nil
+			}); err != nil {
+				return err
+			}
+
 			if err := o.del(); err != nil {
 				return fmt.Errorf("error deleting snapshot : %v", err)
 			}
@@ -182,6 +225,8 @@ func NewCmdSnapshot(base *cli.Base) *cobra.Command { //nolint:gocyclo
 		},
 	}
 
+	bulk.AddDeleteFlags(del, selectorKeys)
+
 	cmd.AddCommand(
 		list,
 		get,
@@ -219,6 +264,39 @@ func (o *options) createURL() (*govultr.Snapshot, error) {
 	return snapshot, err
 }
 
+func (o *options) listAll() ([]govultr.Snapshot, error) {
+	return utils.ListAll(func(opts *govultr.ListOptions) ([]govultr.Snapshot, *govultr.Meta, error) {
+		snapshots, meta, _, err := o.Base.Client.Snapshot.List(o.Base.Context, opts)
+		return snapshots, meta, err
+	})
+}
+
+// selectSnapshots returns the snapshots matching the bulk selector and age
+func (o *options) selectSnapshots(bo *bulk.Options) ([]bulk.Item, error) {
+	snapshots, err := o.listAll()
+	if err != nil {
+		return nil, err
+	}
+
+	var items []bulk.Item
+	for i := range snapshots {
+		if bo.Selector != nil && !bo.Selector.Matches(map[string][]string{
+			"description": {snapshots[i].Description},
+			"status":      {snapshots[i].Status},
+		}) {
+			continue
+		}
+
+		if bo.OlderThan > 0 && !bulk.OlderThan(snapshots[i].DateCreated, bo.OlderThan) {
+			continue
+		}
+
+		items = append(items, bulk.Item{ID: snapshots[i].ID, Name: snapshots[i].Description})
+	}
+
+	return items, nil
+}
+
 func (o *options) del() error {
 	return o.Base.Client.Snapshot.Delete(o.Base.Context, o.Base.Args[0])
 }
@@ -0,0 +1,83 @@
+package summary
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/vultr/vultr-cli/v3/cmd/printer"
+	"github.com/vultr/vultr-cli/v3/cmd/utils"
+)
+
+// SummaryPrinter ...
+type SummaryPrinter struct {
+	Summary *Summary `json:"summary"`
+}
+
+// JSON ...
+func (s *SummaryPrinter) JSON() []byte {
+	return printer.MarshalObject(s, "json")
+}
+
+// YAML ...
+func (s *SummaryPrinter) YAML() []byte {
+	return printer.MarshalObject(s, "yaml")
+}
+
+// Columns ...
+func (s *SummaryPrinter) Columns() [][]string {
+	return [][]string{0: {
+		"TYPE",
+		"REGION",
+		"COUNT",
+		"MONTHLY COST",
+	}}
+}
+
+// Data ...
+func (s *SummaryPrinter) Data() [][]string {
+	if len(s.Summary.Groups) == 0 {
+		return [][]string{0: {"---", "---", "---", "---"}}
+	}
+
+	var data [][]string
+	for i := range s.Summary.Groups {
+		data = append(data, groupRow(&s.Summary.Groups[i], s.Summary.Groups[i].Type, s.Summary.Groups[i].Region))
+	}
+
+	data = append(data, []string{" "})
+	for i := range s.Summary.Types {
+		data = append(data, groupRow(&s.Summary.Types[i], s.Summary.Types[i].Type, "all"))
+	}
+
+	data = append(data, []string{" "})
+	for i := range s.Summary.Regions {
+		data = append(data, groupRow(&s.Summary.Regions[i], "all", s.Summary.Regions[i].Region))
+	}
+
+	return data
+}
+
+// Paging ...
+func (s *SummaryPrinter) Paging() [][]string {
+	return [][]string{
+		0: {"======================================"},
+		1: {"TOTAL RESOURCES", strconv.Itoa(s.Summary.Total)},
+		2: {"ESTIMATED MONTHLY COST", formatCost(s.Summary.MonthlyCost)},
+	}
+}
+
+func groupRow(g *Group, typ, region string) []string {
+	cost := formatCost(g.MonthlyCost)
+	switch {
+	case g.Unpriced == g.Count:
+		cost = "-"
+	case g.Unpriced > 0:
+		cost = fmt.Sprintf("%s (%d unpriced)", cost, g.Unpriced)
+	}
+
+	return []string{typ, region, strconv.Itoa(g.Count), cost}
+}
+
+func formatCost(cost float64) string {
+	return "$" + strconv.FormatFloat(cost, 'f', utils.FloatPrecision, 64)
+}
@@ -0,0 +1,287 @@
+package summary
+
+import (
+	"slices"
+
+	"github.com/vultr/govultr/v3"
+	"github.com/vultr/vultr-cli/v3/cmd/utils"
+)
+
+// priceFunc looks up the monthly price of a plan
+type priceFunc func(id string) (float64, bool, error)
+
+// priced returns the resource priced by the price lookup.  A failed lookup
+// leaves the resource unpriced rather than dropping it from the counts, and
+// the error is reported in the summary.
+func (o *options) priced(typ, region string, price priceFunc, plan string, quantity int) Resource {
+	cost, ok, err := price(plan)
+	if err != nil {
+		o.priceError(err)
+		return Resource{Type: typ, Region: region}
+	}
+	return Resource{Type: typ, Region: region, Cost: cost * float64(quantity), Priced: ok}
+}
+
+// priceError records a failed price lookup once, since the collectors share
+// the lookups and run concurrently
+func (o *options) priceError(err error) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+
+	if !slices.Contains(o.priceErrs, err.Error()) {
+		o.priceErrs = append(o.priceErrs, err.Error())
+	}
+}
+
+func (o *options) instances() ([]Resource, error) {
+	instances, err := utils.ListAll(func(opts *govultr.ListOptions) ([]govultr.Instance, *govultr.Meta, error) {
+		instances, meta, _, err := o.Base.Client.Instance.List(o.Base.Context, opts)
+		return instances, meta, err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	resources := make([]Resource, len(instances))
+	for i := range instances {
+		resources[i] = o.priced(typeInstance, instances[i].Region, o.estimator.PlanPrice, instances[i].Plan, 1)
+	}
+
+	return resources, nil
+}
+
+func (o *options) bareMetals() ([]Resource, error) {
+	bms, err := utils.ListAll(func(opts *govultr.ListOptions) ([]govultr.BareMetalServer, *govultr.Meta, error) {
+		bms, meta, _, err := o.Base.Client.BareMetalServer.List(o.Base.Context, opts)
+		return bms, meta, err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	resources := make([]Resource, len(bms))
+	for i := range bms {
+		resources[i] = o.priced(typeBareMetal, bms[i].Region, o.estimator.BareMetalPrice, bms[i].Plan, 1)
+	}
+
+	return resources, nil
+}
+
+func (o *options) blockStorages() ([]Resource, error) {
+	bss, err := utils.ListAll(func(opts *govultr.ListOptions) ([]govultr.BlockStorage, *govultr.Meta, error) {
+		bss, meta, _, err := o.Base.Client.BlockStorage.List(o.Base.Context, opts)
+		return bss, meta, err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	resources := make([]Resource, len(bss))
+	for i := range bss {
+		resources[i] = Resource{Type: typeBlockStorage, Region: bss[i].Region, Cost: float64(bss[i].Cost), Priced: true}
+	}
+
+	return resources, nil
+}
+
+func (o *options) snapshots() ([]Resource, error) {
+	snapshots, err := utils.ListAll(func(opts *govultr.ListOptions) ([]govultr.Snapshot, *govultr.Meta, error) {
+		snapshots, meta, _, err := o.Base.Client.Snapshot.List(o.Base.Context, opts)
+		return snapshots, meta, err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	resources := make([]Resource, len(snapshots))
+	for i := range snapshots {
+		resources[i] = Resource{Type: typeSnapshot, Region: regionGlobal}
+	}
+
+	return resources, nil
+}
+
+func (o *options) backups() ([]Resource, error) {
+	backups, err := utils.ListAll(func(opts *govultr.ListOptions) ([]govultr.Backup, *govultr.Meta, error) {
+		backups, meta, _, err := o.Base.Client.Backup.List(o.Base.Context, opts)
+		return backups, meta, err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	resources := make([]Resource, len(backups))
+	for i := range backups {
+		resources[i] = Resource{Type: typeBackup, Region: regionGlobal}
+	}
+
+	return resources, nil
+}
+
+func (o *options) reservedIPs() ([]Resource, error) {
+	ips, err := utils.ListAll(func(opts *govultr.ListOptions) ([]govultr.ReservedIP, *govultr.Meta, error) {
+		ips, meta, _, err := o.Base.Client.ReservedIP.List(o.Base.Context, opts)
+		return ips, meta, err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	resources := make([]Resource, len(ips))
+	for i := range ips {
+		resources[i] = Resource{Type: typeReservedIP, Region: ips[i].Region}
+	}
+
+	return resources, nil
+}
+
+func (o *options) loadBalancers() ([]Resource, error) {
+	lbs, err := utils.ListAll(func(opts *govultr.ListOptions) ([]govultr.LoadBalancer, *govultr.Meta, error) {
+		lbs, meta, _, err := o.Base.Client.LoadBalancer.List(o.Base.Context, opts)
+		return lbs, meta, err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	resources := make([]Resource, len(lbs))
+	for i := range lbs {
+		resources[i] = Resource{Type: typeLoadBalancer, Region: lbs[i].Region}
+	}
+
+	return resources, nil
+}
+
+// kubernetesClusters prices the clusters from the plans of their node pools.
+// The control plane is not priced.
+func (o *options) kubernetesClusters() ([]Resource, error) {
+	clusters, err := utils.ListAll(func(opts *govultr.ListOptions) ([]govultr.Cluster, *govultr.Meta, error) {
+		clusters, meta, _, err := o.Base.Client.Kubernetes.ListClusters(o.Base.Context, opts)
+		return clusters, meta, err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	resources := make([]Resource, len(clusters))
+	for i := range clusters {
+		resources[i] = Resource{Type: typeKubernetes, Region: clusters[i].Region, Priced: true}
+		for j := range clusters[i].NodePools {
+			pool := &clusters[i].NodePools[j]
+			r := o.priced(typeKubernetes, clusters[i].Region, o.estimator.PlanPrice, pool.Plan, pool.NodeQuantity)
+			resources[i].Cost += r.Cost
+			resources[i].Priced = resources[i].Priced && r.Priced
+		}
+	}
+
+	return resources, nil
+}
+
+// databases counts the managed databases and their read replicas, which are
+// billed as databases of their own
+func (o *options) databases() ([]Resource, error) {
+	dbs, _, _, err := o.Base.Client.Database.List(o.Base.Context, &govultr.DBListOptions{})
+	if err != nil {
+		return nil, err
+	}
+
+	seen := map[string]bool{}
+	var resources []Resource
+	add := func(db *govultr.Database) {
+		if seen[db.ID] {
+			return
+		}
+		seen[db.ID] = true
+
+		resources = append(resources, o.priced(typeDatabase, db.Region, o.estimator.DatabasePrice, db.Plan, 1))
+	}
+
+	for i := range dbs {
+		add(&dbs[i])
+		for j := range dbs[i].ReadReplicas {
+			add(&dbs[i].ReadReplicas[j])
+		}
+	}
+
+	return resources, nil
+}
+
+func (o *options) objectStorages() ([]Resource, error) {
+	objs, err := utils.ListAll(func(opts *govultr.ListOptions) ([]govultr.ObjectStorage, *govultr.Meta, error) {
+		objs, meta, _, err := o.Base.Client.ObjectStorage.List(o.Base.Context, opts)
+		return objs, meta, err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	resources := make([]Resource, len(objs))
+	for i := range objs {
+		resources[i] = Resource{Type: typeObjectStorage, Region: objs[i].Region}
+		if objs[i].Tier != nil {
+			resources[i].Cost = float64(objs[i].Tier.Price)
+			resources[i].Priced = true
+		}
+	}
+
+	return resources, nil
+}
+
+func (o *options) containerRegistries() ([]Resource, error) {
+	vcrs, err := utils.ListAll(func(opts *govultr.ListOptions) ([]govultr.ContainerRegistry, *govultr.Meta, error) {
+		vcrs, meta, _, err := o.Base.Client.ContainerRegistry.List(o.Base.Context, opts)
+		return vcrs, meta, err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	resources := make([]Resource, len(vcrs))
+	for i := range vcrs {
+		resources[i] = Resource{
+			Type:   typeContainerRegistry,
+			Region: vcrs[i].Metadata.Region.Name,
+			Cost:   float64(vcrs[i].Metadata.Subscription.Billing.MonthlyPrice),
+			Priced: true,
+		}
+	}
+
+	return resources, nil
+}
+
+func (o *options) cdnZones() ([]Resource, error) {
+	pull, _, _, err := o.Base.Client.CDN.ListPullZones(o.Base.Context)
+	if err != nil {
+		return nil, err
+	}
+
+	push, _, _, err := o.Base.Client.CDN.ListPushZones(o.Base.Context)
+	if err != nil {
+		return nil, err
+	}
+
+	resources := make([]Resource, len(pull)+len(push))
+	for i := range resources {
+		resources[i] = Resource{Type: typeCDN, Region: regionGlobal}
+	}
+
+	return resources, nil
+}
+
+func (o *options) domains() ([]Resource, error) {
+	domains, err := utils.ListAll(func(opts *govultr.ListOptions) ([]govultr.Domain, *govultr.Meta, error) {
+		domains, meta, _, err := o.Base.Client.Domain.List(o.Base.Context, opts)
+		return domains, meta, err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// DNS is free of charge
+	resources := make([]Resource, len(domains))
+	for i := range domains {
+		resources[i] = Resource{Type: typeDNS, Region: regionGlobal, Priced: true}
+	}
+
+	return resources, nil
+}
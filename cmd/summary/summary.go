@@ -0,0 +1,226 @@
+// Package summary provides the command summarizing the resources on the
+// account by type and region
+package summary
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"sort"
+	"sync"
+
+	"github.com/spf13/cobra"
+	"github.com/vultr/vultr-cli/v3/cmd/cost"
+	"github.com/vultr/vultr-cli/v3/cmd/utils"
+	"github.com/vultr/vultr-cli/v3/pkg/cli"
+)
+
+const (
+	typeInstance          string = "instance"
+	typeBareMetal         string = "bare-metal"
+	typeBlockStorage      string = "block-storage"
+	typeSnapshot          string = "snapshot"
+	typeBackup            string = "backup"
+	typeReservedIP        string = "reserved-ip"
+	typeLoadBalancer      string = "load-balancer"
+	typeKubernetes        string = "kubernetes"
+	typeDatabase          string = "database"
+	typeObjectStorage     string = "object-storage"
+	typeContainerRegistry string = "container-registry"
+	typeCDN               string = "cdn"
+	typeDNS               string = "dns"
+
+	// regionGlobal is used for resources which are not tied to a region
+	regionGlobal string = "global"
+)
+
+var (
+	long = `Summarize the resources on your account, counted by type and region, with an
+estimated monthly cost.
+
+The resource types are retrieved concurrently.  The cost estimate is built from the
+plan prices of instances, bare metal servers, Kubernetes node pools and managed
+databases, and from the prices reported for block storage, object storage and
+container registries.  Other resources, such as snapshots, backups, reserved IPs,
+load balancers and CDN zones, are counted but not priced, and usage based charges
+such as bandwidth are not included.  Use the billing commands for actual charges.`
+	example = `
+	# Full example
+	vultr-cli summary
+
+	# Summary as JSON
+	vultr-cli summary --output json
+	`
+)
+
+// NewCmdSummary provides the CLI command for the account summary
+func NewCmdSummary(base *cli.Base) *cobra.Command {
+	o := &options{Base: base, estimator: cost.NewEstimator(base)}
+
+	cmd := &cobra.Command{
+		Use:     "summary",
+		Short:   "Summarize the resources on the account",
+		Long:    long,
+		Example: example,
+		PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
+			utils.SetOptions(o.Base, cmd, args)
+			if !o.Base.HasAuth() {
+				return errors.New(utils.APIKeyError)
+			}
+			return nil
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			summary := o.summary()
+			for i := range summary.Errors {
+				fmt.Fprintf(os.Stderr, "warning: %s\n", summary.Errors[i])
+			}
+
+			if summary.failed == len(collectors) {
+				return errors.New("error retrieving the account resources")
+			}
+
+			o.Base.Printer.Display(&SummaryPrinter{Summary: summary}, nil)
+
+			return nil
+		},
+	}
+
+	return cmd
+}
+
+type options struct {
+	Base      *cli.Base
+	estimator *cost.Estimator
+
+	mu        sync.Mutex
+	priceErrs []string
+}
+
+// Resource is a single resource counted in the summary.  Priced is false when
+// no price is known for the resource.
+type Resource struct {
+	Type   string
+	Region string
+	Cost   float64
+	Priced bool
+}
+
+// Group is the count and cost of the resources of a type in a region
+type Group struct {
+	Type        string  `json:"type"`
+	Region      string  `json:"region"`
+	Count       int     `json:"count"`
+	MonthlyCost float64 `json:"monthly_cost"`
+	Unpriced    int     `json:"unpriced"`
+}
+
+// Summary is the account summary
+type Summary struct {
+	Groups      []Group  `json:"groups"`
+	Types       []Group  `json:"types"`
+	Regions     []Group  `json:"regions"`
+	Total       int      `json:"total"`
+	MonthlyCost float64  `json:"monthly_cost"`
+	Errors      []string `json:"errors,omitempty"`
+
+	// failed is the number of collectors which failed
+	failed int
+}
+
+// collector retrieves the resources of a type
+type collector struct {
+	name string
+	list func(o *options) ([]Resource, error)
+}
+
+var collectors = []collector{
+	{"instances", (*options).instances},
+	{"bare metal servers", (*options).bareMetals},
+	{"block storage", (*options).blockStorages},
+	{"snapshots", (*options).snapshots},
+	{"backups", (*options).backups},
+	{"reserved IPs", (*options).reservedIPs},
+	{"load balancers", (*options).loadBalancers},
+	{"kubernetes clusters", (*options).kubernetesClusters},
+	{"databases", (*options).databases},
+	{"object storage", (*options).objectStorages},
+	{"container registries", (*options).containerRegistries},
+	{"CDN zones", (*options).cdnZones},
+	{"DNS domains", (*options).domains},
+}
+
+// summary runs the collectors concurrently and groups their resources.  A
+// failed collector or price lookup is reported in the errors rather than
+// failing the summary.
+func (o *options) summary() *Summary {
+	results := make([][]Resource, len(collectors))
+	errs := make([]error, len(collectors))
+
+	var wg sync.WaitGroup
+	for i := range collectors {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			results[i], errs[i] = collectors[i].list(o)
+		}(i)
+	}
+	wg.Wait()
+
+	var resources []Resource
+	s := &Summary{}
+	for i := range collectors {
+		if errs[i] != nil {
+			s.Errors = append(s.Errors, fmt.Sprintf("error retrieving %s : %v", collectors[i].name, errs[i]))
+			s.failed++
+			continue
+		}
+		resources = append(resources, results[i]...)
+	}
+
+	for i := range o.priceErrs {
+		s.Errors = append(s.Errors, fmt.Sprintf("error pricing resources, they are counted as unpriced : %s", o.priceErrs[i]))
+	}
+
+	s.Groups = group(resources, func(r *Resource) (string, string) { return r.Type, r.Region })
+	s.Types = group(resources, func(r *Resource) (string, string) { return r.Type, "" })
+	s.Regions = group(resources, func(r *Resource) (string, string) { return "", r.Region })
+	for i := range s.Types {
+		s.Total += s.Types[i].Count
+		s.MonthlyCost += s.Types[i].MonthlyCost
+	}
+
+	return s
+}
+
+// group counts the resources by the type and region returned by key
+func group(resources []Resource, key func(r *Resource) (string, string)) []Group {
+	index := map[[2]string]int{}
+	var groups []Group
+	for i := range resources {
+		typ, region := key(&resources[i])
+		k := [2]string{typ, region}
+
+		j, ok := index[k]
+		if !ok {
+			j = len(groups)
+			index[k] = j
+			groups = append(groups, Group{Type: typ, Region: region})
+		}
+
+		groups[j].Count++
+		if resources[i].Priced {
+			groups[j].MonthlyCost += resources[i].Cost
+		} else {
+			groups[j].Unpriced++
+		}
+	}
+
+	sort.Slice(groups, func(i, j int) bool {
+		if groups[i].Type == groups[j].Type {
+			return groups[i].Region < groups[j].Region
+		}
+		return groups[i].Type < groups[j].Type
+	})
+
+	return groups
+}
@@ -3,14 +3,149 @@
 package userdata
 
 import (
+	"bytes"
 	"encoding/base64"
+	"errors"
 	"fmt"
+	"io"
+	"mime"
+	"mime/multipart"
+	"net/textproto"
 	"os"
 	"path/filepath"
+	"sort"
+	"strings"
+	"text/template"
+
+	"gopkg.in/yaml.v3"
+)
+
+const (
+	// MaxSize is the maximum size, in bytes, of the raw user data
+	MaxSize int = 65536
+
+	cloudConfigHeader string = "#cloud-config"
+	multipartBoundary string = "==VULTR-CLI-USERDATA=="
 )
 
+// contentTypes maps the cloud-init user data format headers to their MIME
+// content types
+var contentTypes = []struct {
+	header      string
+	contentType string
+}{
+	{cloudConfigHeader, "text/cloud-config"},
+	{"#cloud-boothook", "text/cloud-boothook"},
+	{"#include", "text/x-include-url"},
+	{"#part-handler", "text/part-handler"},
+	{"## template: jinja", "text/jinja2"},
+	{"#!", "text/x-shellscript"},
+}
+
+// cloudConfigKeys are the top-level keys understood by cloud-init modules
+var cloudConfigKeys = map[string]bool{
+	"allow_public_ssh_keys":      true,
+	"ansible":                    true,
+	"apk_repos":                  true,
+	"apt":                        true,
+	"apt_pipelining":             true,
+	"apt_reboot_if_required":     true,
+	"apt_update":                 true,
+	"apt_upgrade":                true,
+	"autoinstall":                true,
+	"bootcmd":                    true,
+	"byobu_by_default":           true,
+	"ca-certs":                   true,
+	"ca_certs":                   true,
+	"chef":                       true,
+	"chpasswd":                   true,
+	"cloud_config_modules":       true,
+	"cloud_final_modules":        true,
+	"cloud_init_modules":         true,
+	"create_hostname_file":       true,
+	"datasource":                 true,
+	"device_aliases":             true,
+	"disable_ec2_metadata":       true,
+	"disable_root":               true,
+	"disable_root_opts":          true,
+	"disk_setup":                 true,
+	"drivers":                    true,
+	"fan":                        true,
+	"final_message":              true,
+	"fqdn":                       true,
+	"fs_setup":                   true,
+	"groups":                     true,
+	"growpart":                   true,
+	"grub_dpkg":                  true,
+	"hostname":                   true,
+	"keyboard":                   true,
+	"landscape":                  true,
+	"locale":                     true,
+	"locale_configfile":          true,
+	"lxd":                        true,
+	"manage_etc_hosts":           true,
+	"manage_resolv_conf":         true,
+	"mcollective":                true,
+	"merge_how":                  true,
+	"merge_type":                 true,
+	"mount_default_fields":       true,
+	"mounts":                     true,
+	"no_ssh_fingerprints":        true,
+	"ntp":                        true,
+	"output":                     true,
+	"package_reboot_if_required": true,
+	"package_update":             true,
+	"package_upgrade":            true,
+	"packages":                   true,
+	"password":                   true,
+	"phone_home":                 true,
+	"power_state":                true,
+	"prefer_fqdn_over_hostname":  true,
+	"preserve_hostname":          true,
+	"puppet":                     true,
+	"random_seed":                true,
+	"reporting":                  true,
+	"resize_rootfs":              true,
+	"resolv_conf":                true,
+	"rh_subscription":            true,
+	"rsyslog":                    true,
+	"runcmd":                     true,
+	"salt_minion":                true,
+	"snap":                       true,
+	"spacewalk":                  true,
+	"ssh":                        true,
+	"ssh_authorized_keys":        true,
+	"ssh_deletekeys":             true,
+	"ssh_fp_console_blacklist":   true,
+	"ssh_genkeytypes":            true,
+	"ssh_import_id":              true,
+	"ssh_key_console_blacklist":  true,
+	"ssh_keys":                   true,
+	"ssh_publish_hostkeys":       true,
+	"ssh_pwauth":                 true,
+	"ssh_quiet_keygen":           true,
+	"swap":                       true,
+	"system_info":                true,
+	"timezone":                   true,
+	"ubuntu_advantage":           true,
+	"ubuntu_pro":                 true,
+	"updates":                    true,
+	"user":                       true,
+	"users":                      true,
+	"vendor_data":                true,
+	"wireguard":                  true,
+	"write_files":                true,
+	"yum_repo_dir":               true,
+	"yum_repos":                  true,
+	"zypper":                     true,
+}
+
 type UserData struct {
 	Data []byte
+
+	// Warnings are set by Validate for problems which do not make the user
+	// data invalid, for the caller to report
+	Warnings []string
 }
 
 func NewUserDataFromFile(path string) (*UserData, error) {
@@ -32,6 +167,274 @@ func NewUserDataFromString(ud string) *UserData {
 	}
 }
 
+// NewUserDataFromBase64 decodes user data as returned by the API
+func NewUserDataFromBase64(ud string) (*UserData, error) {
+	data, err := base64.StdEncoding.DecodeString(ud)
+	if err != nil {
+		return nil, fmt.Errorf("error decoding base64 user data : %v", err)
+	}
+
+	return &UserData{
+		Data: data,
+	}, nil
+}
+
+// NewUserDataFromFiles reads in the user data from one or more files.  When
+// more than one file is provided, they are assembled into a multi-part MIME
+// archive.  Each file is rendered as a template when vars are provided.
+func NewUserDataFromFiles(paths []string, vars map[string]string) (*UserData, error) {
+	var parts []*UserData
+	for i := range paths {
+		ud, err := NewUserDataFromFile(paths[i])
+		if err != nil {
+			return nil, err
+		}
+
+		if len(vars) > 0 {
+			if err := ud.Render(paths[i], vars); err != nil {
+				return nil, err
+			}
+		}
+
+		parts = append(parts, ud)
+	}
+
+	if len(parts) == 0 {
+		return nil, errors.New("no user data files provided")
+	}
+
+	if len(parts) == 1 {
+		return parts[0], nil
+	}
+
+	return NewMultipartUserData(parts)
+}
+
+// NewMultipartUserData assembles the parts into a cloud-init multi-part MIME
+// archive.  The content type of each part is detected from its header line.
+func NewMultipartUserData(parts []*UserData) (*UserData, error) {
+	var body bytes.Buffer
+	w := multipart.NewWriter(&body)
+	if err := w.SetBoundary(multipartBoundary); err != nil {
+		return nil, fmt.Errorf("error setting user data multi-part boundary : %v", err)
+	}
+
+	for i := range parts {
+		header := textproto.MIMEHeader{}
+		header.Set("Content-Type", fmt.Sprintf("%s; charset=\"utf-8\"", parts[i].ContentType()))
+		header.Set("MIME-Version", "1.0")
+		header.Set("Content-Transfer-Encoding", "7bit")
+		header.Set("Content-Disposition", fmt.Sprintf("attachment; filename=\"part-%03d\"", i+1))
+
+		pw, err := w.CreatePart(header)
+		if err != nil {
+			return nil, fmt.Errorf("error creating user data multi-part : %v", err)
+		}
+
+		if _, err := pw.Write(parts[i].Data); err != nil {
+			return nil, fmt.Errorf("error writing user data multi-part : %v", err)
+		}
+	}
+
+	if err := w.Close(); err != nil {
+		return nil, fmt.Errorf("error closing user data multi-part : %v", err)
+	}
+
+	var ud bytes.Buffer
+	fmt.Fprintf(&ud, "Content-Type: multipart/mixed; boundary=\"%s\"\n", multipartBoundary)
+	ud.WriteString("MIME-Version: 1.0\n\n")
+	ud.Write(body.Bytes())
+
+	return &UserData{Data: ud.Bytes()}, nil
+}
+
+// Build creates the user data from either the text or the files, renders the
+// template vars and validates the result when requested.  It returns nil when
+// neither text nor files are provided.
+func Build(text string, files, vars []string, validate bool) (*UserData, error) {
+	values, err := ParseVars(vars)
+	if err != nil {
+		return nil, err
+	}
+
+	var ud *UserData
+	switch {
+	case text != "":
+		ud = NewUserDataFromString(text)
+		if len(values) > 0 {
+			if err := ud.Render("userdata", values); err != nil {
+				return nil, err
+			}
+		}
+	case len(files) > 0:
+		ud, err = NewUserDataFromFiles(files, values)
+		if err != nil {
+			return nil, err
+		}
+	default:
+		if len(values) > 0 {
+			return nil, errors.New("user data vars were provided without any user data")
+		}
+		return nil, nil
+	}
+
+	if validate {
+		if err := ud.Validate(); err != nil {
+			return nil, err
+		}
+	}
+
+	return ud, nil
+}
+
+// ParseVars converts a list of key=value pairs into a map of template values
+func ParseVars(vars []string) (map[string]string, error) {
+	m := map[string]string{}
+	for i := range vars {
+		key, value, ok := strings.Cut(vars[i], "=")
+		if !ok || strings.TrimSpace(key) == "" {
+			return nil, fmt.Errorf("invalid user data var %q, must be in the form key=value", vars[i])
+		}
+		m[strings.TrimSpace(key)] = value
+	}
+
+	return m, nil
+}
+
 func (u *UserData) Base64Encode() string {
 	return base64.StdEncoding.EncodeToString(u.Data)
 }
+
+// Render executes the user data as a Go template with the provided values,
+// which are accessed as {{ .key }}.  Missing values are an error.
+func (u *UserData) Render(name string, vars map[string]string) error {
+	tmpl, err := template.New(name).Option("missingkey=error").Parse(string(u.Data))
+	if err != nil {
+		return fmt.Errorf("error parsing user data template : %v", err)
+	}
+
+	var out bytes.Buffer
+	if err := tmpl.Execute(&out, vars); err != nil {
+		return fmt.Errorf("error rendering user data template : %v", err)
+	}
+
+	u.Data = out.Bytes()
+
+	return nil
+}
+
+// ContentType returns the MIME content type of the user data based on its
+// header line
+func (u *UserData) ContentType() string {
+	for i := range contentTypes {
+		if bytes.HasPrefix(u.Data, []byte(contentTypes[i].header)) {
+			return contentTypes[i].contentType
+		}
+	}
+
+	return "text/plain"
+}
+
+// Validate checks the user data size and, for cloud-config payloads
+// (including cloud-config parts of a multi-part archive), that the YAML is
+// well-formed.  Top-level keys which are not known cloud-init modules are
+// set as warnings since newer modules may not be listed.
+func (u *UserData) Validate() error {
+	u.Warnings = nil
+
+	if len(u.Data) > MaxSize {
+		return fmt.Errorf("user data is %d bytes which exceeds the maximum of %d bytes", len(u.Data), MaxSize)
+	}
+
+	var err error
+	switch {
+	case bytes.HasPrefix(bytes.TrimSpace(u.Data), []byte("Content-Type:")):
+		u.Warnings, err = validateMultipart(u.Data)
+	case bytes.HasPrefix(u.Data, []byte(cloudConfigHeader)):
+		u.Warnings, err = validateCloudConfig(u.Data)
+	}
+
+	return err
+}
+
+func validateMultipart(data []byte) ([]string, error) {
+	headerEnd := bytes.Index(data, []byte("\n\n"))
+	if headerEnd == -1 {
+		return nil, errors.New("invalid multi-part user data : missing header")
+	}
+
+	var contentType string
+	for _, line := range strings.Split(string(data[:headerEnd]), "\n") {
+		if key, value, ok := strings.Cut(line, ":"); ok && strings.EqualFold(key, "Content-Type") {
+			contentType = strings.TrimSpace(value)
+		}
+	}
+
+	mediaType, params, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		return nil, fmt.Errorf("invalid multi-part user data content type : %v", err)
+	}
+
+	if !strings.HasPrefix(mediaType, "multipart/") {
+		return nil, nil
+	}
+
+	var warnings []string
+	r := multipart.NewReader(bytes.NewReader(data[headerEnd+2:]), params["boundary"])
+	for n := 1; ; n++ {
+		part, err := r.NextPart()
+		if err == io.EOF {
+			return warnings, nil
+		}
+		if err != nil {
+			return nil, fmt.Errorf("invalid multi-part user data : %v", err)
+		}
+
+		body, err := io.ReadAll(part)
+		if err != nil {
+			return nil, fmt.Errorf("error reading multi-part user data part %d : %v", n, err)
+		}
+
+		partType, _, _ := mime.ParseMediaType(part.Header.Get("Content-Type"))
+		if partType == "text/cloud-config" || bytes.HasPrefix(body, []byte(cloudConfigHeader)) {
+			partWarnings, err := validateCloudConfig(body)
+			if err != nil {
+				return nil, fmt.Errorf("multi-part user data part %d : %v", n, err)
+			}
+			for i := range partWarnings {
+				warnings = append(warnings, fmt.Sprintf("multi-part user data part %d : %s", n, partWarnings[i]))
+			}
+		}
+	}
+}
+
+func validateCloudConfig(data []byte) ([]string, error) {
+	var doc interface{}
+	if err := yaml.Unmarshal(data, &doc); err != nil {
+		return nil, fmt.Errorf("invalid cloud-config YAML : %v", err)
+	}
+
+	if doc == nil {
+		return nil, nil
+	}
+
+	cfg, ok := doc.(map[string]interface{})
+	if !ok {
+		return nil, errors.New("invalid cloud-config : the top level must be a mapping of keys")
+	}
+
+	var unknown []string
+	for k := range cfg {
+		if !cloudConfigKeys[k] {
+			unknown = append(unknown, k)
+		}
+	}
+
+	if len(unknown) == 0 {
+		return nil, nil
+	}
+
+	sort.Strings(unknown)
+
+	return []string{fmt.Sprintf("cloud-config has unknown top-level keys %s", strings.Join(unknown, ", "))}, nil
+}
@@ -0,0 +1,210 @@
+package utils
+
+import (
+	"context"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"net/netip"
+	"strings"
+
+	"github.com/vultr/govultr/v3"
+)
+
+const (
+	// DefaultSubnetPool is the pool subnets are allocated from by default
+	DefaultSubnetPool string = "10.0.0.0/8"
+
+	ipv4Bits     int = 32
+	maxAllocBits int = 30
+)
+
+// Network is the subnet of a VPC or VPC2 network in the account
+type Network struct {
+	ID          string
+	Kind        string
+	Region      string
+	Description string
+	Prefix      netip.Prefix
+}
+
+// String formats the network for display in an error
+func (n *Network) String() string {
+	return fmt.Sprintf("%s %s (%s, region: %s)", n.Kind, n.ID, n.Prefix, n.Region)
+}
+
+// AccountNetworks returns the subnets of all the VPC and VPC2 networks in the
+// account.  Networks with an invalid subnet are skipped.
+func AccountNetworks(ctx context.Context, client *govultr.Client) ([]Network, error) {
+	vpcs, err := ListAll(func(opts *govultr.ListOptions) ([]govultr.VPC, *govultr.Meta, error) {
+		vpcs, meta, _, err := client.VPC.List(ctx, opts)
+		return vpcs, meta, err
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error listing vpcs : %v", err)
+	}
+
+	vpc2s, err := ListAll(func(opts *govultr.ListOptions) ([]govultr.VPC2, *govultr.Meta, error) {
+		vpc2s, meta, _, err := client.VPC2.List(ctx, opts) //nolint:staticcheck
+		return vpc2s, meta, err
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error listing vpc2s : %v", err)
+	}
+
+	var networks []Network
+	for i := range vpcs {
+		p, err := ParseSubnet(vpcs[i].V4Subnet, vpcs[i].V4SubnetMask)
+		if err != nil {
+			continue
+		}
+		networks = append(networks, Network{
+			ID:          vpcs[i].ID,
+			Kind:        "vpc",
+			Region:      vpcs[i].Region,
+			Description: vpcs[i].Description,
+			Prefix:      p,
+		})
+	}
+
+	for i := range vpc2s {
+		p, err := ParseSubnet(vpc2s[i].IPBlock, vpc2s[i].PrefixLength)
+		if err != nil {
+			continue
+		}
+		networks = append(networks, Network{
+			ID:          vpc2s[i].ID,
+			Kind:        "vpc2",
+			Region:      vpc2s[i].Region,
+			Description: vpc2s[i].Description,
+			Prefix:      p,
+		})
+	}
+
+	return networks, nil
+}
+
+// ParseSubnet parses an IPv4 subnet address and mask size
+func ParseSubnet(subnet string, size int) (netip.Prefix, error) {
+	p, err := netip.ParsePrefix(fmt.Sprintf("%s/%d", subnet, size))
+	if err != nil || !p.Addr().Is4() {
+		return netip.Prefix{}, fmt.Errorf("invalid IPv4 subnet %s/%d", subnet, size)
+	}
+	return p.Masked(), nil
+}
+
+// CheckSubnet returns an error listing the networks which overlap the subnet
+func CheckSubnet(p netip.Prefix, networks []Network) error {
+	var conflicts []string
+	for i := range networks {
+		if networks[i].Prefix.Overlaps(p) {
+			conflicts = append(conflicts, networks[i].String())
+		}
+	}
+
+	if len(conflicts) > 0 {
+		return fmt.Errorf("subnet %s overlaps with : %s", p, strings.Join(conflicts, ", "))
+	}
+
+	return nil
+}
+
+// AllocateSubnet returns the first subnet of the size within the pool which
+// does not overlap any of the networks.  Candidates overlapping a network are
+// skipped past the end of that network rather than one at a time, so large
+// pools are searched in as many steps as there are networks.
+func AllocateSubnet(pool string, size int, networks []Network) (netip.Prefix, error) {
+	p, err := netip.ParsePrefix(pool)
+	if err != nil || !p.Addr().Is4() {
+		return netip.Prefix{}, fmt.Errorf("invalid IPv4 subnet pool %s", pool)
+	}
+	p = p.Masked()
+
+	if size < p.Bits() || size > maxAllocBits {
+		return netip.Prefix{}, fmt.Errorf("size must be between %d and %d for the pool %s", p.Bits(), maxAllocBits, p)
+	}
+
+	start := uint64(addrUint32(p.Addr()))
+	end := start + uint64(1)<<(ipv4Bits-p.Bits())
+	step := uint64(1) << (ipv4Bits - size)
+
+	for next := start; next < end; {
+		var b [4]byte
+		binary.BigEndian.PutUint32(b[:], uint32(next)) //nolint:gosec
+		candidate := netip.PrefixFrom(netip.AddrFrom4(b), size)
+
+		// Skip to the first aligned subnet after the furthest reaching
+		// network overlapping the candidate
+		skip := next
+		for i := range networks {
+			if !networks[i].Prefix.Overlaps(candidate) {
+				continue
+			}
+			last := uint64(addrUint32(networks[i].Prefix.Masked().Addr())) +
+				uint64(1)<<(ipv4Bits-networks[i].Prefix.Bits())
+			skip = max(skip, last)
+		}
+
+		if skip == next {
+			return candidate, nil
+		}
+
+		next = (skip + step - 1) / step * step
+	}
+
+	return netip.Prefix{}, fmt.Errorf("no free /%d subnet is left in the pool %s", size, p)
+}
+
+// addrUint32 returns the IPv4 address as an integer
+func addrUint32(addr netip.Addr) uint32 {
+	b := addr.As4()
+	return binary.BigEndian.Uint32(b[:])
+}
+
+// SubnetRequest is the subnet requested on VPC creation
+type SubnetRequest struct {
+	Subnet string
+	Size   int
+	// Auto allocates the subnet from the Pool
+	Auto         bool
+	Pool         string
+	AllowOverlap bool
+}
+
+// Resolve returns the subnet to create the network with.  With Auto the
+// subnet is allocated from the pool, otherwise an explicit subnet is checked
+// for overlaps with the other networks in the account unless AllowOverlap is
+// set.
+func (r *SubnetRequest) Resolve(ctx context.Context, client *govultr.Client) (string, error) {
+	if !r.Auto && (r.Subnet == "" || r.Size == 0 || r.AllowOverlap) {
+		return r.Subnet, nil
+	}
+
+	if r.Auto && r.Size == 0 {
+		return "", errors.New("a subnet size is required to allocate a subnet")
+	}
+
+	networks, err := AccountNetworks(ctx, client)
+	if err != nil {
+		return "", err
+	}
+
+	if r.Auto {
+		p, err := AllocateSubnet(r.Pool, r.Size, networks)
+		if err != nil {
+			return "", err
+		}
+		return p.Addr().String(), nil
+	}
+
+	p, err := ParseSubnet(r.Subnet, r.Size)
+	if err != nil {
+		return "", err
+	}
+
+	if err := CheckSubnet(p, networks); err != nil {
+		return "", fmt.Errorf("%v, use --allow-overlap to create it anyway", err)
+	}
+
+	return r.Subnet, nil
+}
@@ -0,0 +1,172 @@
+package utils
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+// Resource describes a resource in a confirmation prompt and is matched
+// against the deletion protection rules
+type Resource struct {
+	Kind   string
+	ID     string
+	Label  string
+	Region string
+	Tags   []string
+	// Note is appended to the prompt to describe any side effects
+	Note string
+}
+
+// String formats the resource for display in a prompt
+func (r *Resource) String() string {
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "%s %s", r.Kind, r.ID)
+
+	var details []string
+	if r.Label != "" && r.Label != r.ID {
+		details = append(details, fmt.Sprintf("label: %s", r.Label))
+	}
+	if r.Region != "" {
+		details = append(details, fmt.Sprintf("region: %s", r.Region))
+	}
+	if len(details) > 0 {
+		fmt.Fprintf(&sb, " (%s)", strings.Join(details, ", "))
+	}
+
+	if r.Note != "" {
+		fmt.Fprintf(&sb, " %s", r.Note)
+	}
+
+	return sb.String()
+}
+
+// Protection holds the deletion protection rules read from the 'protect'
+// section of the config file.  Labels are glob patterns as used by path.Match.
+//
+//	protect:
+//	  ids:
+//	    - 2126b7d9-5e2a-491e-8840-838aa6b5f294
+//	  labels:
+//	    - prod-*
+//	  tags:
+//	    - production
+type Protection struct {
+	IDs    []string
+	Labels []string
+	Tags   []string
+}
+
+// GetProtection returns the deletion protection rules from the config
+func GetProtection() *Protection {
+	return &Protection{
+		IDs:    viper.GetStringSlice("protect.ids"),
+		Labels: viper.GetStringSlice("protect.labels"),
+		Tags:   viper.GetStringSlice("protect.tags"),
+	}
+}
+
+// Empty returns whether there are no protection rules
+func (p *Protection) Empty() bool {
+	return len(p.IDs) == 0 && len(p.Labels) == 0 && len(p.Tags) == 0
+}
+
+// Match returns the rule protecting the resource or an empty string when the
+// resource is not protected
+func (p *Protection) Match(r *Resource) string {
+	for i := range p.IDs {
+		if p.IDs[i] == r.ID {
+			return fmt.Sprintf("id %s", p.IDs[i])
+		}
+	}
+
+	for i := range p.Labels {
+		if ok, _ := path.Match(p.Labels[i], r.Label); ok && r.Label != "" {
+			return fmt.Sprintf("label pattern %s", p.Labels[i])
+		}
+	}
+
+	for i := range p.Tags {
+		for j := range r.Tags {
+			if p.Tags[i] == r.Tags[j] {
+				return fmt.Sprintf("tag %s", p.Tags[i])
+			}
+		}
+	}
+
+	return ""
+}
+
+// AssumeYes returns whether confirmation prompts are skipped through the
+// VULTR_CLI_ASSUME_YES environment variable or 'assume-yes' in the config
+func AssumeYes() bool {
+	return viper.GetBool("assume-yes")
+}
+
+// AddConfirmFlags adds the --yes and --force flags used by ConfirmDelete
+func AddConfirmFlags(cmd *cobra.Command) {
+	cmd.Flags().BoolP("yes", "y", false, "(optional) Skip the confirmation prompt")
+	cmd.Flags().Bool("force", false, "(optional) Delete resources covered by the deletion protection in the config")
+}
+
+// ConfirmDelete checks the resource against the deletion protection rules and
+// prompts for confirmation on stderr, unless skipped by --yes or
+// VULTR_CLI_ASSUME_YES.  The resource is only retrieved through get when it is
+// needed.
+func ConfirmDelete(cmd *cobra.Command, get func() (*Resource, error)) error {
+	yes, errYe := cmd.Flags().GetBool("yes")
+	if errYe != nil {
+		return fmt.Errorf("error parsing flag 'yes' : %v", errYe)
+	}
+
+	force, errFo := cmd.Flags().GetBool("force")
+	if errFo != nil {
+		return fmt.Errorf("error parsing flag 'force' : %v", errFo)
+	}
+
+	yes = yes || AssumeYes()
+	protection := GetProtection()
+	if yes && (force || protection.Empty()) {
+		return nil
+	}
+
+	r, err := get()
+	if err != nil {
+		return fmt.Errorf("error retrieving resource to confirm deletion : %v", err)
+	}
+
+	if rule := protection.Match(r); rule != "" && !force {
+		return fmt.Errorf("%s is protected from deletion by %s, use --force to delete it", r, rule)
+	}
+
+	if yes {
+		return nil
+	}
+
+	return Prompt(os.Stdin, os.Stderr, fmt.Sprintf("Delete %s?", r))
+}
+
+// Prompt writes the question and reads a yes or no answer.  Anything other than
+// yes, including no input, aborts with an error.
+func Prompt(in io.Reader, out io.Writer, question string) error {
+	fmt.Fprintf(out, "%s [y/N]: ", question)
+
+	answer, err := bufio.NewReader(in).ReadString('\n')
+	if err != nil && err != io.EOF {
+		return fmt.Errorf("error reading confirmation : %v", err)
+	}
+
+	switch strings.ToLower(strings.TrimSpace(answer)) {
+	case "y", "yes":
+		return nil
+	default:
+		return errors.New("operation aborted, use --yes or set VULTR_CLI_ASSUME_YES to skip the confirmation prompt")
+	}
+}
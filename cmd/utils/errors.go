@@ -1,8 +1,29 @@
 package utils
 
+import "fmt"
+
 const (
 	// APIKeyError is the error used when missing the API key on commands which
 	// require it
 	//nolint:gosec
 	APIKeyError string = `set VULTR_API_KEY as an environment variable or add 'api-key' to your config file`
 )
+
+// ExitError is returned by commands which exit with a specific code, such as
+// the exit code of a client they ran.  Execute exits with the code.  Err is
+// the error reported, if any.
+type ExitError struct {
+	Code int
+	Err  error
+}
+
+func (e *ExitError) Error() string {
+	if e.Err != nil {
+		return e.Err.Error()
+	}
+	return fmt.Sprintf("exit status %d", e.Code)
+}
+
+func (e *ExitError) Unwrap() error {
+	return e.Err
+}
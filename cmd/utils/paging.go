@@ -21,3 +21,23 @@ func GetPaging(cmd *cobra.Command) *govultr.ListOptions {
 
 	return options
 }
+
+// ListAll calls list with the cursor of each page until the last page and
+// returns the items of every page
+func ListAll[T any](list func(opts *govultr.ListOptions) ([]T, *govultr.Meta, error)) ([]T, error) {
+	var all []T
+	opts := &govultr.ListOptions{PerPage: PerPageDefault}
+	for {
+		items, meta, err := list(opts)
+		if err != nil {
+			return nil, err
+		}
+
+		all = append(all, items...)
+
+		if meta == nil || meta.Links == nil || meta.Links.Next == "" {
+			return all, nil
+		}
+		opts.Cursor = meta.Links.Next
+	}
+}
@@ -2,9 +2,13 @@
 package utils
 
 import (
+	"errors"
 	"fmt"
+	"slices"
+	"strings"
 
 	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
 	"github.com/spf13/viper"
 	"github.com/vultr/vultr-cli/v3/pkg/cli"
 )
@@ -34,3 +38,20 @@ func GetFirewallSource(source string) string {
 func FormatFirewallNetwork(subnet string, size int) string {
 	return fmt.Sprintf("%s/%d", subnet, size)
 }
+
+// FileOnly returns an error when any of the command's own flags, other than
+// the --file flag and the allowed flags, were set.  It is used by commands
+// which read all of their settings from a spec file.
+func FileOnly(cmd *cobra.Command, allowed ...string) error {
+	var changed []string
+	cmd.LocalNonPersistentFlags().VisitAll(func(f *pflag.Flag) {
+		if f.Changed && f.Name != "file" && !slices.Contains(allowed, f.Name) {
+			changed = append(changed, "--"+f.Name)
+		}
+	})
+
+	if len(changed) > 0 {
+		return errors.New("the --file flag cannot be combined with " + strings.Join(changed, ", "))
+	}
+	return nil
+}
@@ -0,0 +1,111 @@
+package vpc
+
+import (
+	"fmt"
+	"net/netip"
+	"sort"
+
+	"github.com/vultr/govultr/v3"
+	"github.com/vultr/vultr-cli/v3/cmd/utils"
+)
+
+const (
+	ipv4Bits int = 32
+	// reservedAddresses are the network and broadcast addresses of a subnet
+	reservedAddresses int = 2
+)
+
+// Address is an internal IP allocated in a VPC
+type Address struct {
+	IP         string `json:"ip"`
+	OwnerType  string `json:"owner_type"`
+	OwnerID    string `json:"owner_id"`
+	OwnerLabel string `json:"owner_label"`
+	MACAddress string `json:"mac_address,omitempty"`
+}
+
+// Capacity is the address usage of a VPC subnet
+type Capacity struct {
+	Subnet    string `json:"subnet"`
+	Total     int    `json:"total"`
+	Usable    int    `json:"usable"`
+	Allocated int    `json:"allocated"`
+	Free      int    `json:"free"`
+}
+
+// Overlap is another VPC or VPC2 network whose subnet overlaps
+type Overlap struct {
+	ID          string `json:"id"`
+	Type        string `json:"type"`
+	Region      string `json:"region"`
+	Description string `json:"description"`
+	Subnet      string `json:"subnet"`
+}
+
+// vpcPrefix returns the subnet of the VPC
+func vpcPrefix(vpc *govultr.VPC) (netip.Prefix, error) {
+	p, err := utils.ParseSubnet(vpc.V4Subnet, vpc.V4SubnetMask)
+	if err != nil {
+		return netip.Prefix{}, fmt.Errorf("invalid subnet for vpc %s : %v", vpc.ID, err)
+	}
+	return p, nil
+}
+
+// prefixSize returns the number of addresses in the IPv4 prefix
+func prefixSize(p netip.Prefix) int {
+	return 1 << (ipv4Bits - p.Bits())
+}
+
+// capacity returns the usage of the subnet by the allocated addresses.  Only
+// distinct addresses within the subnet are counted.
+func capacity(p netip.Prefix, addresses []Address) Capacity {
+	c := Capacity{Subnet: p.String(), Total: prefixSize(p)}
+	c.Usable = max(c.Total-reservedAddresses, 0)
+
+	seen := map[netip.Addr]bool{}
+	for i := range addresses {
+		ip, err := netip.ParseAddr(addresses[i].IP)
+		if err != nil || !p.Contains(ip) {
+			continue
+		}
+		seen[ip] = true
+	}
+
+	c.Allocated = len(seen)
+	c.Free = max(c.Usable-c.Allocated, 0)
+
+	return c
+}
+
+// overlaps returns the VPC and VPC2 networks, other than the one with the ID,
+// whose subnet overlaps with the prefix
+func overlaps(p netip.Prefix, id string, networks []utils.Network) []Overlap {
+	var found []Overlap
+	for i := range networks {
+		if networks[i].ID == id || !networks[i].Prefix.Overlaps(p) {
+			continue
+		}
+
+		found = append(found, Overlap{
+			ID:          networks[i].ID,
+			Type:        networks[i].Kind,
+			Region:      networks[i].Region,
+			Description: networks[i].Description,
+			Subnet:      networks[i].Prefix.String(),
+		})
+	}
+
+	return found
+}
+
+// sortAddresses sorts the addresses by IP, with unparsable addresses last
+func sortAddresses(addresses []Address) {
+	sort.SliceStable(addresses, func(i, j int) bool {
+		a, errA := netip.ParseAddr(addresses[i].IP)
+		b, errB := netip.ParseAddr(addresses[j].IP)
+		if errA != nil || errB != nil {
+			return errB != nil && errA == nil
+		}
+		return a.Less(b)
+	})
+}
@@ -411,3 +411,147 @@ func (fwr *NATGatewayFirewallRulePrinter) Data() [][]string {
 func (fwr *NATGatewayFirewallRulePrinter) Paging() [][]string {
 	return nil
 }
+
+// ======================================
+
+// AddressesPrinter ...
+type AddressesPrinter struct {
+	Addresses []Address `json:"addresses"`
+	Capacity  Capacity  `json:"capacity"`
+	Overlaps  []Overlap `json:"overlapping_networks"`
+}
+
+// JSON ...
+func (a *AddressesPrinter) JSON() []byte {
+	return printer.MarshalObject(a, "json")
+}
+
+// YAML ...
+func (a *AddressesPrinter) YAML() []byte {
+	return printer.MarshalObject(a, "yaml")
+}
+
+// Columns ...
+func (a *AddressesPrinter) Columns() [][]string {
+	return nil
+}
+
+// Data ...
+func (a *AddressesPrinter) Data() [][]string {
+	var data [][]string
+	data = append(data,
+		[]string{"SUBNET", a.Capacity.Subnet},
+		[]string{"TOTAL", strconv.Itoa(a.Capacity.Total)},
+		[]string{"USABLE", strconv.Itoa(a.Capacity.Usable)},
+		[]string{"ALLOCATED", strconv.Itoa(a.Capacity.Allocated)},
+		[]string{"FREE", strconv.Itoa(a.Capacity.Free)},
+		[]string{" "},
+		[]string{"ADDRESSES"},
+		[]string{"IP", "OWNER TYPE", "OWNER ID", "OWNER LABEL", "MAC ADDRESS"},
+	)
+
+	if len(a.Addresses) == 0 {
+		data = append(data, []string{"---", "---", "---", "---", "---"})
+	}
+
+	for i := range a.Addresses {
+		data = append(data, []string{
+			a.Addresses[i].IP,
+			a.Addresses[i].OwnerType,
+			a.Addresses[i].OwnerID,
+			a.Addresses[i].OwnerLabel,
+			a.Addresses[i].MACAddress,
+		})
+	}
+
+	data = append(data,
+		[]string{" "},
+		[]string{"OVERLAPPING NETWORKS"},
+		[]string{"ID", "TYPE", "REGION", "DESCRIPTION", "SUBNET"},
+	)
+
+	if len(a.Overlaps) == 0 {
+		data = append(data, []string{"---", "---", "---", "---", "---"})
+	}
+
+	for i := range a.Overlaps {
+		data = append(data, []string{
+			a.Overlaps[i].ID,
+			a.Overlaps[i].Type,
+			a.Overlaps[i].Region,
+			a.Overlaps[i].Description,
+			a.Overlaps[i].Subnet,
+		})
+	}
+
+	return data
+}
+
+// Paging ...
+func (a *AddressesPrinter) Paging() [][]string {
+	return nil
+}
+
+// ======================================
+
+// RulesApplyPrinter ...
+type RulesApplyPrinter struct {
+	Changes []RuleChange `json:"changes"`
+	DryRun  bool         `json:"dry_run"`
+}
+
+// JSON ...
+func (r *RulesApplyPrinter) JSON() []byte {
+	return printer.MarshalObject(r, "json")
+}
+
+// YAML ...
+func (r *RulesApplyPrinter) YAML() []byte {
+	return printer.MarshalObject(r, "yaml")
+}
+
+// Columns ...
+func (r *RulesApplyPrinter) Columns() [][]string {
+	return [][]string{0: {
+		"ACTION",
+		"TYPE",
+		"ID",
+		"RULE",
+	}}
+}
+
+// Data ...
+func (r *RulesApplyPrinter) Data() [][]string {
+	if len(r.Changes) == 0 {
+		return [][]string{0: {"---", "---", "---", "---"}}
+	}
+
+	var data [][]string
+	for i := range r.Changes {
+		id := r.Changes[i].ID
+		if id == "" {
+			id = "---"
+		}
+
+		data = append(data, []string{
+			r.Changes[i].Action,
+			r.Changes[i].Type,
+			id,
+			r.Changes[i].Rule,
+		})
+	}
+
+	return data
+}
+
+// Paging ...
+func (r *RulesApplyPrinter) Paging() [][]string {
+	if !r.DryRun {
+		return nil
+	}
+
+	return [][]string{
+		0: {"======================================"},
+		1: {"DRY RUN: no changes have been made"},
+	}
+}
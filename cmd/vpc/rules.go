@@ -0,0 +1,454 @@
+package vpc
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/vultr/govultr/v3"
+	"github.com/vultr/vultr-cli/v3/cmd/utils"
+	"gopkg.in/yaml.v3"
+)
+
+const (
+	ruleTypePortForwarding string = "port-forwarding"
+	ruleTypeFirewall       string = "firewall"
+
+	ruleActionCreate    string = "create"
+	ruleActionUpdate    string = "update"
+	ruleActionDelete    string = "delete"
+	ruleActionUnchanged string = "unchanged"
+)
+
+// RuleSet is the document of NAT gateway rules used by rules export and apply
+type RuleSet struct {
+	PortForwardingRules []PortForwardingRuleSpec `yaml:"port_forwarding_rules"`
+	FirewallRules       []FirewallRuleSpec       `yaml:"firewall_rules"`
+}
+
+// PortForwardingRuleSpec is a port forwarding rule of a RuleSet.  Rules are
+// matched on the protocol and external port.
+type PortForwardingRuleSpec struct {
+	Name         string `yaml:"name"`
+	Description  string `yaml:"description,omitempty"`
+	Protocol     string `yaml:"protocol"`
+	ExternalPort int    `yaml:"external_port"`
+	InternalIP   string `yaml:"internal_ip"`
+	InternalPort int    `yaml:"internal_port"`
+	Enabled      *bool  `yaml:"enabled,omitempty"`
+}
+
+// FirewallRuleSpec is a firewall rule of a RuleSet.  Rules are matched on the
+// protocol, port and subnet since only the notes can be updated.
+type FirewallRuleSpec struct {
+	Protocol   string `yaml:"protocol"`
+	Port       string `yaml:"port"`
+	Subnet     string `yaml:"subnet"`
+	SubnetSize int    `yaml:"subnet_size"`
+	Notes      string `yaml:"notes,omitempty"`
+}
+
+// RuleChange is the outcome of applying a single NAT gateway rule
+type RuleChange struct {
+	Action string `json:"action"`
+	Type   string `json:"type"`
+	ID     string `json:"id"`
+	Rule   string `json:"rule"`
+}
+
+func (r *PortForwardingRuleSpec) key() string {
+	return fmt.Sprintf("%s/%d", strings.ToLower(r.Protocol), r.ExternalPort)
+}
+
+func (r *PortForwardingRuleSpec) String() string {
+	return fmt.Sprintf("%s %d -> %s:%d", r.Protocol, r.ExternalPort, r.InternalIP, r.InternalPort)
+}
+
+func (r *FirewallRuleSpec) key() string {
+	return fmt.Sprintf("%s/%s/%s/%d", strings.ToLower(r.Protocol), r.Port, r.Subnet, r.SubnetSize)
+}
+
+func (r *FirewallRuleSpec) String() string {
+	return fmt.Sprintf("%s %s from %s", r.Protocol, r.Port, utils.FormatFirewallNetwork(r.Subnet, r.SubnetSize))
+}
+
+func portForwardingSpec(r *govultr.NATGatewayPortForwardingRule) PortForwardingRuleSpec {
+	return PortForwardingRuleSpec{
+		Name:         r.Name,
+		Description:  r.Description,
+		Protocol:     r.Protocol,
+		ExternalPort: r.ExternalPort,
+		InternalIP:   r.InternalIP,
+		InternalPort: r.InternalPort,
+		Enabled:      r.Enabled,
+	}
+}
+
+func firewallSpec(r *govultr.NATGatewayFirewallRule) FirewallRuleSpec {
+	return FirewallRuleSpec{
+		Protocol:   r.Protocol,
+		Port:       r.Port,
+		Subnet:     r.Subnet,
+		SubnetSize: r.SubnetSize,
+		Notes:      r.Notes,
+	}
+}
+
+// NewRuleSetFromFile reads in a YAML or JSON rules file.  Unknown fields are
+// an error to catch typos before calling the API.
+func NewRuleSetFromFile(path string) (*RuleSet, error) {
+	fd, err := os.ReadFile(filepath.Clean(path))
+	if err != nil {
+		return nil, fmt.Errorf("error reading rules file : %v", err)
+	}
+
+	dec := yaml.NewDecoder(bytes.NewReader(fd))
+	dec.KnownFields(true)
+
+	rs := &RuleSet{}
+	if err := dec.Decode(rs); err != nil {
+		return nil, fmt.Errorf("error parsing rules file : %v", err)
+	}
+
+	return rs, rs.validate()
+}
+
+// validate checks the rules locally, including rules which would match the
+// same gateway rule
+func (rs *RuleSet) validate() error {
+	var errs []string
+	seen := map[string]bool{}
+	for i := range rs.PortForwardingRules {
+		r := &rs.PortForwardingRules[i]
+		if r.Name == "" || r.Protocol == "" || r.InternalIP == "" || r.ExternalPort == 0 || r.InternalPort == 0 {
+			errs = append(errs, fmt.Sprintf(
+				"port_forwarding_rules[%d] requires name, protocol, external_port, internal_ip and internal_port",
+				i,
+			))
+		}
+
+		if seen[r.key()] {
+			errs = append(errs, fmt.Sprintf("port_forwarding_rules[%d] duplicates the rule for %s", i, r.key()))
+		}
+		seen[r.key()] = true
+	}
+
+	for i := range rs.FirewallRules {
+		r := &rs.FirewallRules[i]
+		if r.Protocol == "" || r.Port == "" || r.Subnet == "" {
+			errs = append(errs, fmt.Sprintf("firewall_rules[%d] requires protocol, port and subnet", i))
+		}
+
+		if seen[r.key()] {
+			errs = append(errs, fmt.Sprintf("firewall_rules[%d] duplicates the rule for %s", i, r))
+		}
+		seen[r.key()] = true
+	}
+
+	if len(errs) > 0 {
+		return fmt.Errorf("invalid rules file :\n  %s", strings.Join(errs, "\n  "))
+	}
+
+	return nil
+}
+
+// YAML returns the rule set as a YAML document
+func (rs *RuleSet) YAML() ([]byte, error) {
+	var b bytes.Buffer
+	enc := yaml.NewEncoder(&b)
+	enc.SetIndent(2) //nolint:mnd
+
+	if err := enc.Encode(rs); err != nil {
+		return nil, fmt.Errorf("error marshaling rules : %v", err)
+	}
+
+	return b.Bytes(), nil
+}
+
+// ruleSet returns the current rules of the NAT gateway
+func (o *options) ruleSet() (
+	*RuleSet,
+	[]govultr.NATGatewayPortForwardingRule,
+	[]govultr.NATGatewayFirewallRule,
+	error,
+) {
+	pfRules, err := utils.ListAll(
+		func(opts *govultr.ListOptions) ([]govultr.NATGatewayPortForwardingRule, *govultr.Meta, error) {
+			rules, meta, _, err := o.Base.Client.VPC.ListNATGatewayPortForwardingRules(
+				o.Base.Context,
+				o.Base.Args[0],
+				o.Base.Args[1],
+				opts,
+			)
+			return rules, meta, err
+		},
+	)
+	if err != nil {
+		return nil, nil, nil, fmt.Errorf("error listing port forwarding rules : %v", err)
+	}
+
+	fwRules, err := utils.ListAll(
+		func(opts *govultr.ListOptions) ([]govultr.NATGatewayFirewallRule, *govultr.Meta, error) {
+			rules, meta, _, err := o.Base.Client.VPC.ListNATGatewayFirewallRules(
+				o.Base.Context,
+				o.Base.Args[0],
+				o.Base.Args[1],
+				opts,
+			)
+			return rules, meta, err
+		},
+	)
+	if err != nil {
+		return nil, nil, nil, fmt.Errorf("error listing firewall rules : %v", err)
+	}
+
+	rs := &RuleSet{}
+	for i := range pfRules {
+		rs.PortForwardingRules = append(rs.PortForwardingRules, portForwardingSpec(&pfRules[i]))
+	}
+
+	for i := range fwRules {
+		rs.FirewallRules = append(rs.FirewallRules, firewallSpec(&fwRules[i]))
+	}
+
+	return rs, pfRules, fwRules, nil
+}
+
+// applyRules reconciles the NAT gateway rules with the rule set.  Rules on
+// the gateway which are not in the rule set are deleted.  The changes made up
+// to an error are returned along with it.
+func (o *options) applyRules(rs *RuleSet, dryRun bool) ([]RuleChange, error) { //nolint:funlen,gocyclo
+	_, pfRules, fwRules, err := o.ruleSet()
+	if err != nil {
+		return nil, err
+	}
+
+	var changes []RuleChange
+	// matched holds the IDs of the gateway rules which are in the rule set,
+	// any other rule is deleted
+	matched := map[string]bool{}
+
+	// Port forwarding rules
+	existingPF := map[string]*govultr.NATGatewayPortForwardingRule{}
+	for i := range pfRules {
+		spec := portForwardingSpec(&pfRules[i])
+		existingPF[spec.key()] = &pfRules[i]
+	}
+
+	for i := range rs.PortForwardingRules {
+		want := &rs.PortForwardingRules[i]
+		req := &govultr.NATGatewayPortForwardingRuleReq{
+			Name:         want.Name,
+			Description:  want.Description,
+			InternalIP:   want.InternalIP,
+			Protocol:     want.Protocol,
+			ExternalPort: want.ExternalPort,
+			InternalPort: want.InternalPort,
+			Enabled:      want.Enabled,
+		}
+
+		current, ok := existingPF[want.key()]
+		if !ok {
+			change := RuleChange{Action: ruleActionCreate, Type: ruleTypePortForwarding, Rule: want.String()}
+			if !dryRun {
+				rule, _, err := o.Base.Client.VPC.CreateNATGatewayPortForwardingRule(
+					o.Base.Context,
+					o.Base.Args[0],
+					o.Base.Args[1],
+					req,
+				)
+				if err != nil {
+					return changes, fmt.Errorf("error creating port forwarding rule %s : %v", want, err)
+				}
+				change.ID = rule.ID
+			}
+			changes = append(changes, change)
+			continue
+		}
+		matched[current.ID] = true
+
+		change := RuleChange{Action: ruleActionUnchanged, Type: ruleTypePortForwarding, ID: current.ID, Rule: want.String()}
+		if !portForwardingEqual(want, current) {
+			change.Action = ruleActionUpdate
+			if !dryRun {
+				if _, _, err := o.Base.Client.VPC.UpdateNATGatewayPortForwardingRule(
+					o.Base.Context,
+					o.Base.Args[0],
+					o.Base.Args[1],
+					current.ID,
+					req,
+				); err != nil {
+					return changes, fmt.Errorf("error updating port forwarding rule %s : %v", current.ID, err)
+				}
+			}
+		}
+		changes = append(changes, change)
+	}
+
+	for i := range pfRules {
+		if matched[pfRules[i].ID] {
+			continue
+		}
+		spec := portForwardingSpec(&pfRules[i])
+
+		if !dryRun {
+			if err := o.Base.Client.VPC.DeleteNATGatewayPortForwardingRule(
+				o.Base.Context,
+				o.Base.Args[0],
+				o.Base.Args[1],
+				pfRules[i].ID,
+			); err != nil {
+				return changes, fmt.Errorf("error deleting port forwarding rule %s : %v", pfRules[i].ID, err)
+			}
+		}
+		changes = append(changes, RuleChange{
+			Action: ruleActionDelete,
+			Type:   ruleTypePortForwarding,
+			ID:     pfRules[i].ID,
+			Rule:   spec.String(),
+		})
+	}
+
+	// Firewall rules
+	existingFW := map[string]*govultr.NATGatewayFirewallRule{}
+	for i := range fwRules {
+		spec := firewallSpec(&fwRules[i])
+		existingFW[spec.key()] = &fwRules[i]
+	}
+
+	for i := range rs.FirewallRules {
+		want := &rs.FirewallRules[i]
+		current, ok := existingFW[want.key()]
+		if !ok {
+			change := RuleChange{Action: ruleActionCreate, Type: ruleTypeFirewall, Rule: want.String()}
+			if !dryRun {
+				rule, _, err := o.Base.Client.VPC.CreateNATGatewayFirewallRule(
+					o.Base.Context,
+					o.Base.Args[0],
+					o.Base.Args[1],
+					&govultr.NATGatewayFirewallRuleCreateReq{
+						Protocol:   want.Protocol,
+						Port:       want.Port,
+						Subnet:     want.Subnet,
+						SubnetSize: want.SubnetSize,
+						Notes:      want.Notes,
+					},
+				)
+				if err != nil {
+					return changes, fmt.Errorf("error creating firewall rule %s : %v", want, err)
+				}
+				change.ID = rule.ID
+			}
+			changes = append(changes, change)
+			continue
+		}
+		matched[current.ID] = true
+
+		change := RuleChange{Action: ruleActionUnchanged, Type: ruleTypeFirewall, ID: current.ID, Rule: want.String()}
+		if want.Notes != current.Notes {
+			change.Action = ruleActionUpdate
+			if !dryRun {
+				if _, _, err := o.Base.Client.VPC.UpdateNATGatewayFirewallRule(
+					o.Base.Context,
+					o.Base.Args[0],
+					o.Base.Args[1],
+					current.ID,
+					&govultr.NATGatewayFirewallRuleUpdateReq{Notes: want.Notes},
+				); err != nil {
+					return changes, fmt.Errorf("error updating firewall rule %s : %v", current.ID, err)
+				}
+			}
+		}
+		changes = append(changes, change)
+	}
+
+	for i := range fwRules {
+		if matched[fwRules[i].ID] {
+			continue
+		}
+		spec := firewallSpec(&fwRules[i])
+
+		if !dryRun {
+			if err := o.Base.Client.VPC.DeleteNATGatewayFirewallRule(
+				o.Base.Context,
+				o.Base.Args[0],
+				o.Base.Args[1],
+				fwRules[i].ID,
+			); err != nil {
+				return changes, fmt.Errorf("error deleting firewall rule %s : %v", fwRules[i].ID, err)
+			}
+		}
+		changes = append(changes, RuleChange{
+			Action: ruleActionDelete,
+			Type:   ruleTypeFirewall,
+			ID:     fwRules[i].ID,
+			Rule:   spec.String(),
+		})
+	}
+
+	return changes, nil
+}
+
+// portForwardingEqual returns whether the gateway rule matches the spec.  An
+// unset enabled field in the spec matches any state.
+func portForwardingEqual(want *PortForwardingRuleSpec, current *govultr.NATGatewayPortForwardingRule) bool {
+	if want.Name != current.Name ||
+		want.Description != current.Description ||
+		want.InternalIP != current.InternalIP ||
+		want.InternalPort != current.InternalPort {
+		return false
+	}
+
+	if want.Enabled != nil {
+		enabled := current.Enabled == nil || *current.Enabled
+		return *want.Enabled == enabled
+	}
+
+	return true
+}
+
+// ruleCounts summarizes the changes by action
+func ruleCounts(changes []RuleChange) string {
+	counts := map[string]int{}
+	for i := range changes {
+		counts[changes[i].Action]++
+	}
+
+	var parts []string
+	for _, action := range []string{ruleActionCreate, ruleActionUpdate, ruleActionDelete, ruleActionUnchanged} {
+		parts = append(parts, action+": "+strconv.Itoa(counts[action]))
+	}
+
+	return strings.Join(parts, ", ")
+}
+
+// confirmRuleDeletes plans the rule set and prompts for confirmation on stderr
+// when rules on the NAT Gateway would be deleted
+func (o *options) confirmRuleDeletes(rs *RuleSet) error {
+	plan, err := o.applyRules(rs, true)
+	if err != nil {
+		return fmt.Errorf("error planning NAT Gateway rules : %v", err)
+	}
+
+	var deletes []RuleChange
+	for i := range plan {
+		if plan[i].Action == ruleActionDelete {
+			deletes = append(deletes, plan[i])
+		}
+	}
+
+	if len(deletes) == 0 {
+		return nil
+	}
+
+	fmt.Fprintf(os.Stderr, "The following rules are not in the rules file and will be deleted:\n")
+	for i := range deletes {
+		fmt.Fprintf(os.Stderr, "  %s %s (%s)\n", deletes[i].Type, deletes[i].Rule, deletes[i].ID)
+	}
+	fmt.Fprintf(os.Stderr, "%s\n", ruleCounts(plan))
+
+	return utils.Prompt(os.Stdin, os.Stderr, "Apply these changes?")
+}
@@ -5,6 +5,7 @@ import (
 	"errors"
 	"fmt"
 	"os"
+	"strings"
 
 	"github.com/spf13/cobra"
 	"github.com/vultr/govultr/v3"
@@ -35,6 +36,16 @@ var (
 	# Shortened example with aliases
 	vultr-cli vpc g 9fd4dcf5-7108-4641-9969-b2b9a8f77990
 	`
+	addressesLong = `List every internal IP allocated in a VPC along with its owner.
+
+The attached instances and bare metal servers and the NAT gateways of the VPC
+are listed with the free capacity of the subnet, which excludes the network and
+broadcast addresses.  The VPC and VPC2 networks in the account with an
+overlapping subnet are also listed.`
+	addressesExample = `
+	# Full example
+	vultr-cli vpc addresses 9fd4dcf5-7108-4641-9969-b2b9a8f77990
+	`
 	createLong    = `Create a new VPC with desired options`
 	createExample = `
 	# Full example
@@ -44,6 +55,12 @@ var (
 
 	# Shortened example with aliases
 	vultr-cli vpc c -r="ewr" -d="Example VPC" -s="10.200.0.0" -z=24
+
+	# Allocate the next free /24 from 10.0.0.0/8
+	vultr-cli vpc create --region="ewr" --description="Example VPC" --auto-subnet --pool="10.0.0.0/8" --size=24
+
+	An explicit --subnet is rejected when it overlaps the subnet of another VPC
+	in the account, unless --allow-overlap is passed.
 	`
 	updateLong    = `Update an existing VPC with the supplied information`
 	updateExample = `
@@ -236,6 +253,58 @@ var (
 	vultr-cli vpc ng fr d e3512e83-64e9-4d3e-a401-9b86f2e09b1d efc811e7-d07d-45b1-b50e-19a0c2936ce0 \
 		74fb2217-de13-4ca2-8065-0c16281a7849
 	`
+	rulesLong    = `Export and apply the port forwarding and firewall rules of a NAT Gateway as a single file`
+	rulesExample = `
+	# Export the rules of a NAT Gateway
+	vultr-cli vpc nat-gateway rules export e3512e83-64e9-4d3e-a401-9b86f2e09b1d \
+		efc811e7-d07d-45b1-b50e-19a0c2936ce0 > rules.yaml
+
+	# Apply the rules file to a NAT Gateway
+	vultr-cli vpc nat-gateway rules apply e3512e83-64e9-4d3e-a401-9b86f2e09b1d \
+		efc811e7-d07d-45b1-b50e-19a0c2936ce0 -f rules.yaml
+	`
+	rulesExportLong = `Print the port forwarding and firewall rules of a NAT Gateway as a YAML rules file which can be
+used with rules apply`
+	rulesExportExample = `
+	# Full example
+	vultr-cli vpc nat-gateway rules export e3512e83-64e9-4d3e-a401-9b86f2e09b1d \
+		efc811e7-d07d-45b1-b50e-19a0c2936ce0 > rules.yaml
+	`
+	rulesApplyLong = `Reconcile the port forwarding and firewall rules of a NAT Gateway with a YAML or JSON rules file.
+
+Port forwarding rules are matched on their protocol and external port, firewall rules on their protocol, port and
+subnet.  Matching rules which differ are updated, rules only in the file are created and rules only on the NAT
+Gateway are deleted.  The rules to delete are listed on stderr with a confirmation prompt first, unless skipped by
+--yes or VULTR_CLI_ASSUME_YES.  The rules file has the following format:
+
+	port_forwarding_rules:
+	  - name: web
+	    description: web server
+	    protocol: tcp
+	    external_port: 80
+	    internal_ip: 10.1.0.5
+	    internal_port: 8080
+	    enabled: true
+	firewall_rules:
+	  - protocol: tcp
+	    port: "80"
+	    subnet: 0.0.0.0
+	    subnet_size: 0
+	    notes: web traffic
+`
+	rulesApplyExample = `
+	# Full example
+	vultr-cli vpc nat-gateway rules apply e3512e83-64e9-4d3e-a401-9b86f2e09b1d \
+		efc811e7-d07d-45b1-b50e-19a0c2936ce0 --file rules.yaml
+
+	# Show the changes without applying them
+	vultr-cli vpc ng rules apply e3512e83-64e9-4d3e-a401-9b86f2e09b1d \
+		efc811e7-d07d-45b1-b50e-19a0c2936ce0 -f rules.yaml --dry-run
+
+	# Delete the rules missing from the file without a confirmation prompt
+	vultr-cli vpc ng rules apply e3512e83-64e9-4d3e-a401-9b86f2e09b1d \
+		efc811e7-d07d-45b1-b50e-19a0c2936ce0 -f rules.yaml --yes
+	`
 )
 
 // NewCmdVPC provides the CLI command for VPC functions
@@ -342,6 +411,34 @@ func NewCmdVPC(base *cli.Base) *cobra.Command { //nolint:gocyclo,funlen
 				return fmt.Errorf("error parsing flag 'size' for vpc create : %v", errSi)
 			}
 
+			autoSubnet, errAu := cmd.Flags().GetBool("auto-subnet")
+			if errAu != nil {
+				return fmt.Errorf("error parsing flag 'auto-subnet' for vpc create : %v", errAu)
+			}
+
+			pool, errPo := cmd.Flags().GetString("pool")
+			if errPo != nil {
+				return fmt.Errorf("error parsing flag 'pool' for vpc create : %v", errPo)
+			}
+
+			allowOverlap, errAl := cmd.Flags().GetBool("allow-overlap")
+			if errAl != nil {
+				return fmt.Errorf("error parsing flag 'allow-overlap' for vpc create : %v", errAl)
+			}
+
+			req := &utils.SubnetRequest{
+				Subnet:       subnet,
+				Size:         size,
+				Auto:         autoSubnet,
+				Pool:         pool,
+				AllowOverlap: allowOverlap,
+			}
+
+			subnet, errCh := req.Resolve(o.Base.Context, o.Base.Client)
+			if errCh != nil {
+				return errCh
+			}
+
 			o.CreateReq = &govultr.VPCReq{
 				Region:       region,
 				Description:  description,
@@ -370,6 +467,14 @@ func NewCmdVPC(base *cli.Base) *cobra.Command { //nolint:gocyclo,funlen
 	create.Flags().StringP("description", "d", "", "The description of the VPC")
 	create.Flags().StringP("subnet", "s", "", "The IPv4 VPC in CIDR notation.")
 	create.Flags().IntP("size", "z", 0, "The number of bits for the netmask in CIDR notation.")
+	create.Flags().Bool(
+		"auto-subnet",
+		false,
+		"(optional) Allocate the next subnet of --size from --pool which does not overlap any VPC in the account",
+	)
+	create.Flags().String("pool", utils.DefaultSubnetPool, "(optional) The subnet pool used by --auto-subnet")
+	create.Flags().Bool("allow-overlap", false, "(optional) Skip the check for subnets overlapping other VPCs")
+	create.MarkFlagsMutuallyExclusive("subnet", "auto-subnet")
 
 	// Update
 	update := &cobra.Command{
@@ -432,6 +537,51 @@ func NewCmdVPC(base *cli.Base) *cobra.Command { //nolint:gocyclo,funlen
 		},
 	}
 
+	// Addresses
+	addresses := &cobra.Command{
+		Use:     "addresses <VPC ID>",
+		Aliases: []string{"addrs", "ipam"},
+		Short:   "List the internal IPs allocated in a VPC",
+		Long:    addressesLong,
+		Example: addressesExample,
+		Args: func(cmd *cobra.Command, args []string) error {
+			if len(args) < 1 {
+				return errors.New("please provide a VPC ID")
+			}
+			return nil
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			vpc, err := o.get()
+			if err != nil {
+				return fmt.Errorf("error retrieving vpc : %v", err)
+			}
+
+			prefix, err := vpcPrefix(vpc)
+			if err != nil {
+				return err
+			}
+
+			addrs, err := o.addresses()
+			if err != nil {
+				return fmt.Errorf("error retrieving vpc addresses : %v", err)
+			}
+
+			networks, err := utils.AccountNetworks(o.Base.Context, o.Base.Client)
+			if err != nil {
+				return err
+			}
+
+			data := &AddressesPrinter{
+				Addresses: addrs,
+				Capacity:  capacity(prefix, addrs),
+				Overlaps:  overlaps(prefix, vpc.ID, networks),
+			}
+			o.Base.Printer.Display(data, nil)
+
+			return nil
+		},
+	}
+
 	// NAT Gateway
 	natGateway := &cobra.Command{
 		Use:     "nat-gateway",
@@ -1088,6 +1238,112 @@ func NewCmdVPC(base *cli.Base) *cobra.Command { //nolint:gocyclo,funlen
 		firewallRuleDelete,
 	)
 
+	// NAT Gateway Rules
+	rules := &cobra.Command{
+		Use:     "rules",
+		Short:   "Commands to export and apply NAT Gateway rules",
+		Long:    rulesLong,
+		Example: rulesExample,
+	}
+
+	// NAT Gateway Rules Export
+	rulesExport := &cobra.Command{
+		Use:     "export <VPC ID> <NAT Gateway ID>",
+		Short:   "Export NAT Gateway rules to a rules file",
+		Long:    rulesExportLong,
+		Example: rulesExportExample,
+		Args: func(cmd *cobra.Command, args []string) error {
+			if len(args) != 2 {
+				return errors.New("please provide a VPC ID and a NAT Gateway ID")
+			}
+			return nil
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			rs, _, _, err := o.ruleSet()
+			if err != nil {
+				return fmt.Errorf("error retrieving NAT Gateway rules : %v", err)
+			}
+
+			out, err := rs.YAML()
+			if err != nil {
+				return err
+			}
+
+			if _, err := os.Stdout.Write(out); err != nil {
+				return fmt.Errorf("error writing NAT Gateway rules : %v", err)
+			}
+
+			return nil
+		},
+	}
+
+	// NAT Gateway Rules Apply
+	rulesApply := &cobra.Command{
+		Use:     "apply <VPC ID> <NAT Gateway ID>",
+		Short:   "Apply a rules file to a NAT Gateway",
+		Long:    rulesApplyLong,
+		Example: rulesApplyExample,
+		Args: func(cmd *cobra.Command, args []string) error {
+			if len(args) != 2 {
+				return errors.New("please provide a VPC ID and a NAT Gateway ID")
+			}
+			return nil
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			file, errFi := cmd.Flags().GetString("file")
+			if errFi != nil {
+				return fmt.Errorf("error parsing flag 'file' for NAT Gateway rules apply : %v", errFi)
+			}
+
+			dryRun, errDr := cmd.Flags().GetBool("dry-run")
+			if errDr != nil {
+				return fmt.Errorf("error parsing flag 'dry-run' for NAT Gateway rules apply : %v", errDr)
+			}
+
+			yes, errYe := cmd.Flags().GetBool("yes")
+			if errYe != nil {
+				return fmt.Errorf("error parsing flag 'yes' for NAT Gateway rules apply : %v", errYe)
+			}
+
+			rs, err := NewRuleSetFromFile(file)
+			if err != nil {
+				return err
+			}
+
+			if !dryRun && !yes && !utils.AssumeYes() {
+				if errCo := o.confirmRuleDeletes(rs); errCo != nil {
+					return errCo
+				}
+			}
+
+			changes, err := o.applyRules(rs, dryRun)
+			if err != nil {
+				if len(changes) > 0 {
+					fmt.Fprintf(os.Stderr, "changes applied before the error : %s\n", ruleCounts(changes))
+				}
+				return fmt.Errorf("error applying NAT Gateway rules : %v", err)
+			}
+
+			data := &RulesApplyPrinter{Changes: changes, DryRun: dryRun}
+			o.Base.Printer.Display(data, nil)
+
+			return nil
+		},
+	}
+
+	rulesApply.Flags().StringP("file", "f", "", "path to the YAML or JSON rules file")
+	if err := rulesApply.MarkFlagRequired("file"); err != nil {
+		fmt.Printf("error marking NAT Gateway rules apply 'file' flag required: %v", err)
+		os.Exit(1)
+	}
+	rulesApply.Flags().Bool("dry-run", false, "(optional) Display the changes without applying them.")
+	rulesApply.Flags().BoolP("yes", "y", false, "(optional) Skip the confirmation prompt")
+
+	rules.AddCommand(
+		rulesExport,
+		rulesApply,
+	)
+
 	natGateway.AddCommand(
 		natGatewayList,
 		natGatewayGet,
@@ -1096,6 +1352,7 @@ func NewCmdVPC(base *cli.Base) *cobra.Command { //nolint:gocyclo,funlen
 		natGatewayDelete,
 		portForwardingRule,
 		firewallRule,
+		rules,
 	)
 
 	cmd.AddCommand(
@@ -1104,6 +1361,7 @@ func NewCmdVPC(base *cli.Base) *cobra.Command { //nolint:gocyclo,funlen
 		create,
 		update,
 		del,
+		addresses,
 		natGateway,
 	)
 
@@ -1266,3 +1524,78 @@ func (o *options) updateNATGatewayFirewallRule() (*govultr.NATGatewayFirewallRul
 func (o *options) delNATGatewayFirewallRule() error {
 	return o.Base.Client.VPC.DeleteNATGatewayFirewallRule(o.Base.Context, o.Base.Args[0], o.Base.Args[1], o.Base.Args[2])
 }
+
+// addresses returns the internal IPs of the resources attached to the VPC and
+// of its NAT gateways, sorted by IP
+func (o *options) addresses() ([]Address, error) {
+	attachments, err := utils.ListAll(func(opts *govultr.ListOptions) ([]govultr.VPCAttachment, *govultr.Meta, error) {
+		attachments, meta, _, err := o.Base.Client.VPC.ListAttachments(o.Base.Context, o.Base.Args[0], opts)
+		return attachments, meta, err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	var addrs []Address
+	for i := range attachments {
+		a := Address{
+			IP:         attachments[i].IP.V4,
+			OwnerType:  attachments[i].LinkedSubscription.Type,
+			OwnerID:    attachments[i].LinkedSubscription.ID,
+			MACAddress: attachments[i].MACAddress,
+		}
+
+		if a.OwnerType == "" {
+			a.OwnerType = attachments[i].Type
+		}
+
+		if a.OwnerID == "" {
+			a.OwnerID = attachments[i].ID
+		}
+
+		a.OwnerLabel = o.ownerLabel(a.OwnerType, a.OwnerID)
+		addrs = append(addrs, a)
+	}
+
+	gateways, err := utils.ListAll(func(opts *govultr.ListOptions) ([]govultr.NATGateway, *govultr.Meta, error) {
+		gateways, meta, _, err := o.Base.Client.VPC.ListNATGateways(o.Base.Context, o.Base.Args[0], opts)
+		return gateways, meta, err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	for i := range gateways {
+		for j := range gateways[i].PrivateIPs {
+			addrs = append(addrs, Address{
+				IP:         gateways[i].PrivateIPs[j],
+				OwnerType:  "nat_gateway",
+				OwnerID:    gateways[i].ID,
+				OwnerLabel: gateways[i].Label,
+			})
+		}
+	}
+
+	sortAddresses(addrs)
+
+	return addrs, nil
+}
+
+// ownerLabel retrieves the label of the instance or bare metal server owning
+// an attachment.  Lookup failures leave the label empty.
+func (o *options) ownerLabel(ownerType, id string) string {
+	switch {
+	case strings.Contains(ownerType, "bare"):
+		bm, _, err := o.Base.Client.BareMetalServer.Get(o.Base.Context, id)
+		if err == nil {
+			return bm.Label
+		}
+	case ownerType == "instance" || ownerType == "vps":
+		instance, _, err := o.Base.Client.Instance.Get(o.Base.Context, id)
+		if err == nil {
+			return instance.Label
+		}
+	}
+
+	return ""
+}
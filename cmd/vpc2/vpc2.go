@@ -160,6 +160,39 @@ func NewCmdVPC2(base *cli.Base) *cobra.Command { //nolint:gocyclo
 				return fmt.Errorf("error parsing flag 'prefix-length' for vpc2 create : %v", errPr)
 			}
 
+			autoSubnet, errAu := cmd.Flags().GetBool("auto-subnet")
+			if errAu != nil {
+				return fmt.Errorf("error parsing flag 'auto-subnet' for vpc2 create : %v", errAu)
+			}
+
+			pool, errPo := cmd.Flags().GetString("pool")
+			if errPo != nil {
+				return fmt.Errorf("error parsing flag 'pool' for vpc2 create : %v", errPo)
+			}
+
+			allowOverlap, errAl := cmd.Flags().GetBool("allow-overlap")
+			if errAl != nil {
+				return fmt.Errorf("error parsing flag 'allow-overlap' for vpc2 create : %v", errAl)
+			}
+
+			req := &utils.SubnetRequest{
+				Subnet:       ipBlock,
+				Size:         prefixLen,
+				Auto:         autoSubnet,
+				Pool:         pool,
+				AllowOverlap: allowOverlap,
+			}
+
+			ipBlock, errCh := req.Resolve(o.Base.Context, o.Base.Client)
+			if errCh != nil {
+				return errCh
+			}
+
+			// allocated subnets are always IPv4
+			if autoSubnet && ipType == "" {
+				ipType = "v4"
+			}
+
 			o.CreateReq = &govultr.VPC2Req{
 				Region:       region,
 				Description:  description,
@@ -188,7 +221,7 @@ func NewCmdVPC2(base *cli.Base) *cobra.Command { //nolint:gocyclo
 	}
 
 	create.Flags().StringP("description", "d", "", "description for the new VPC2 network")
-	create.Flags().StringP("ip-type", "", "", "IP type for the new VPC2 network")
+	create.Flags().StringP("ip-type", "", "", "IP type for the new VPC2 network. Defaults to v4 with --auto-subnet")
 	create.Flags().StringP("ip-block", "", "", "subnet IP address for the new VPC2 network")
 	create.Flags().IntP(
 		"prefix-length",
@@ -196,6 +229,14 @@ func NewCmdVPC2(base *cli.Base) *cobra.Command { //nolint:gocyclo
 		0,
 		"number of bits for the netmask in CIDR notation for the new VPC2 network",
 	)
+	create.Flags().Bool(
+		"auto-subnet",
+		false,
+		"(optional) Allocate the next subnet of --prefix-length from --pool which does not overlap any VPC in the account",
+	)
+	create.Flags().String("pool", utils.DefaultSubnetPool, "(optional) The subnet pool used by --auto-subnet")
+	create.Flags().Bool("allow-overlap", false, "(optional) Skip the check for subnets overlapping other VPCs")
+	create.MarkFlagsMutuallyExclusive("ip-block", "auto-subnet")
 
 	// Update
 	update := &cobra.Command{